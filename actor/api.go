@@ -1,5 +1,11 @@
 package actor
 
+import (
+	"context"
+	"github.com/strategicpause/slashie/logger"
+	"time"
+)
+
 // Actor defines the operations that each actor implements.
 type Actor interface {
 	// GetType returns the type of Actor.
@@ -9,21 +15,58 @@ type Actor interface {
 	// GetKey will return the key for the given Actor which is a globally unique identifier. The Key is a composite
 	// of the actor Type and Id.
 	GetKey() Key
-	// Notify will send a message to the given actor's Mailbox.
+	// GetLogger returns the Logger for the actor, which prefixes each message with the actor's Key.
+	GetLogger() logger.Logger
+	// Notify will send a Message closure to the given actor. Message closures are kept apart from the actor's
+	// Mailbox, so they are never dropped by its OverflowPolicy, but are processed after the messages sent before them.
 	Notify(message Message)
-	// RegisterMessageHandler will register a Handler function for a given message type.
+	// TryNotify behaves like Notify, but returns an ActorStoppedError if the actor has stopped. It never blocks.
+	TryNotify(message Message) error
+	// RegisterMessageHandler will register a Handler function for a given message type, which is either a
+	// MessageType returned by TypeOf, or a sample message of the type.
 	RegisterMessageHandler(messageType any, handler Handler)
+	// RegisterReplyHandler will register a ReplyHandler function for a given message type, as with
+	// RegisterMessageHandler. The reply is returned to callers of Ask, and is discarded for messages sent through
+	// SendMessage.
+	RegisterReplyHandler(messageType any, handler ReplyHandler)
 	// SendMessage allows an actor to receive an arbitrary message. If the actor does not support the given message
 	// type, then an error will be returned.
 	SendMessage(message any) error
+	// TrySendMessage sends a message to the actor without blocking. A MailboxFullError is returned if the mailbox is
+	// full. Unlike SendMessage, the message type is not checked before the message is added to the mailbox, so
+	// messages without a Handler are dropped once they are received.
+	TrySendMessage(message any) error
+	// TrySendMessageUntil behaves like TrySendMessage, but waits until the deadline for room in the mailbox.
+	TrySendMessageUntil(deadline time.Time, message any) error
+	// Ask sends a message to the actor and waits for the reply from its handler. Messages handled by a Handler reply
+	// with nil. An error is returned if the actor does not support the given message type, if the actor stops before
+	// replying, or if the context is done.
+	Ask(ctx context.Context, message any) (any, error)
+	// CurrentEnvelope returns the Envelope for the message which is currently being handled. It must only be called
+	// from a handler. Messages which were not sent as an Envelope are wrapped in one when they are sent, so that each
+	// message has an Id.
+	CurrentEnvelope() Envelope
+	// OnDeadLetter sets the handler which receives each message that the actor could not deliver, either because it
+	// has stopped, has no Handler for the message type, or its mailbox dropped the message. Message closures are never
+	// passed to the handler.
+	OnDeadLetter(handler DeadLetterHandler)
 	// Init will initialize the event loop for handling messages that get sent to the actor's mailbox.
 	Init()
+	// Start runs the event loop in its own goroutine, if it is not already running.
+	Start()
 	// Stop will stop all event processing and kill the underlying goroutine.
 	Stop()
 	// Wait will block until the actor has stopped or reached its terminal status.
 	Wait()
 }
 
+// MailboxLenReporter is implemented by actors which can report the number of messages waiting to be processed, such
+// as BasicActor.
+type MailboxLenReporter interface {
+	// MailboxLen returns the number of messages waiting in the actor's Mailbox.
+	MailboxLen() int
+}
+
 // Registry is a central repository to register & fetch actors.
 type Registry interface {
 	// RegisterActor will register an Actor and return the corresponding actor Key.
@@ -33,6 +76,14 @@ type Registry interface {
 	GetActor(actorKey Key) (Actor, bool)
 	// IsRegistered returns true if the given actor was registered with via RegisterActor.
 	IsRegistered(actor Actor) bool
+	// ListActors returns each registered Actor, in the order in which they were registered.
+	ListActors() []Actor
+	// ListByType returns each registered Actor of the given Type, in the order in which they were registered.
+	ListByType(actorType Type) []Actor
+	// ListByKeyPattern returns each registered Actor whose Key matches the given glob pattern, in the order in which
+	// they were registered. The pattern uses the syntax of path.Match, so "Worker:*" matches every actor of type
+	// Worker. An InvalidKeyPatternError is returned if the pattern is malformed.
+	ListByKeyPattern(pattern string) ([]Actor, error)
 }
 
 // StatusManager keeps track of actor statues including the initial, terminal, desired, and known status.
@@ -41,7 +92,8 @@ type StatusManager interface {
 	// will both be set to the initial status.
 	InitializeActor(actorKey Key, initStatus Status, terminalStatus Status)
 	// IsValidSubscriptionStatus returns true if the actor can transition to the given status. You cannot
-	// subscribe to a status which has already past.
+	// subscribe to a status which has already past, or to a status which the actor is currently in, including the
+	// parents of its known status.
 	IsValidSubscriptionStatus(actorKey Key, status Status) bool
 	// IsValidTransitionStatus returns false for illegal transitions including using the terminal status as the source
 	// or using the init status as the destination.
@@ -58,4 +110,36 @@ type StatusManager interface {
 	GetInitialStatus(actorKey Key) Status
 	// GetTerminalStatus returns the terminal status for the given actor Key.
 	GetTerminalStatus(actorKey Key) Status
+	// EnqueuePendingStatus adds a status which the given actor Key will transition to once the current transition
+	// has completed. Returns false if the status was rejected by the PendingStatusPolicy.
+	EnqueuePendingStatus(actorKey Key, status Status) bool
+	// DequeuePendingStatus removes and returns the next status which the given actor Key should transition to. The
+	// second return value is false if there are no pending statuses.
+	DequeuePendingStatus(actorKey Key) (Status, bool)
+	// ClearPendingStatuses removes all pending statuses for the given actor Key.
+	ClearPendingStatuses(actorKey Key)
+	// SetRoute sets the remaining statuses which the given actor Key will transition through, in order, after its
+	// current transition has completed.
+	SetRoute(actorKey Key, route []Status)
+	// NextRouteStatus removes and returns the next status in the route for the given actor Key. The second return
+	// value is false if the route is empty.
+	NextRouteStatus(actorKey Key) (Status, bool)
+	// ClearRoute removes the route for the given actor Key.
+	ClearRoute(actorKey Key)
+	// SetCancelledStatus sets the status which the given actor Key moves to when a transition is cancelled.
+	SetCancelledStatus(actorKey Key, status Status)
+	// GetCancelledStatus returns the status which the given actor Key moves to when a transition is cancelled. The
+	// second return value is false if no cancelled status has been set.
+	GetCancelledStatus(actorKey Key) (Status, bool)
+	// AddChildStatus nests the childStatus under the parentStatus for the given actor Key. While an actor is in the
+	// childStatus, it is also considered to be in the parentStatus.
+	AddChildStatus(actorKey Key, parentStatus Status, childStatus Status)
+	// GetParentStatus returns the parent of the given status for the given actor Key. The second return value is
+	// false if the status has no parent.
+	GetParentStatus(actorKey Key, status Status) (Status, bool)
+	// GetLineage returns the given status followed by each of its ancestors, ordered from the innermost to the
+	// outermost status.
+	GetLineage(actorKey Key, status Status) []Status
+	// IsInLineage returns true if the ancestor is the given status, or one of its ancestors, for the given actor Key.
+	IsInLineage(actorKey Key, status Status, ancestor Status) bool
 }
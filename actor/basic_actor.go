@@ -1,9 +1,14 @@
 package actor
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/strategicpause/slashie/clock"
+	"github.com/strategicpause/slashie/logger"
 	"reflect"
 	"sync"
+	"time"
 )
 
 const (
@@ -13,48 +18,307 @@ const (
 type BasicActor struct {
 	actorType Type
 	actorId   Id
-	mailbox   mailbox
-	stopChan  chan bool
-	wg        sync.WaitGroup
-	handlers  map[reflect.Type]Handler
+	mailbox   Mailbox
+	// control holds the Message closures sent to the actor, which are processed in order with the messages in the
+	// mailbox.
+	control controlQueue
+	// pending is a message taken from the mailbox which is waiting on a Message closure sent before it, and is only
+	// accessed from the event loop.
+	pending *sequenced
+	// stopCtx is cancelled once the actor has stopped.
+	stopCtx context.Context
+	stop    context.CancelFunc
+	wg      sync.WaitGroup
+	// handlers are matched against each message in order, with handlers for concrete types ahead of those for
+	// interfaces.
+	handlers []registeredHandler
+	// deadLetterHandler receives messages which could not be delivered.
+	deadLetterMu      sync.RWMutex
+	deadLetterHandler DeadLetterHandler
+	logger            logger.Logger
+	// panicHandler receives any panics recovered from handlers. If nil, panics are not recovered.
+	panicHandler PanicHandler
+	middleware   []Middleware
+	clock        clock.Clock
+	deferStart   bool
+	startOnce    sync.Once
+	// currentEnvelope is the Envelope for the message being handled, and is only accessed from the event loop.
+	currentEnvelope Envelope
 }
 
-func NewBasicActor(actorType Type, actorId Id) *BasicActor {
+type BasicActorOpt func(ba *BasicActor)
+
+// WithMailbox sets the Mailbox used to store incoming messages. By default, a bounded mailbox of
+// DefaultMailBoxSize messages is used, which blocks senders while it is full.
+func WithMailbox(mailbox Mailbox) BasicActorOpt {
+	return func(ba *BasicActor) {
+		ba.mailbox = mailbox
+	}
+}
+
+// WithMailboxSize sets the size of the default bounded mailbox.
+func WithMailboxSize(size int) BasicActorOpt {
+	return func(ba *BasicActor) {
+		ba.mailbox = NewBoundedMailbox(size, OverflowBlock)
+	}
+}
+
+// WithLogger sets the Logger for the actor. Each message is prefixed with the actor's Key. By default, nothing is
+// logged.
+func WithLogger(l logger.Logger) BasicActorOpt {
+	return func(ba *BasicActor) {
+		ba.logger = l
+	}
+}
+
+// WithPanicHandler recovers any panic from a handler, and passes it to the given PanicHandler. The actor continues
+// processing messages, and callers of Ask receive a HandlerPanicError. By default, panics are not recovered.
+func WithPanicHandler(handler PanicHandler) BasicActorOpt {
+	return func(ba *BasicActor) {
+		ba.panicHandler = handler
+	}
+}
+
+// WithMiddleware wraps each handler registered through RegisterMessageHandler or RegisterReplyHandler with the given
+// Middleware. The first Middleware is the outermost.
+func WithMiddleware(middleware ...Middleware) BasicActorOpt {
+	return func(ba *BasicActor) {
+		ba.middleware = append(ba.middleware, middleware...)
+	}
+}
+
+// WithClock sets the Clock used to timestamp dead letters. By default, the real clock is used.
+func WithClock(c clock.Clock) BasicActorOpt {
+	return func(ba *BasicActor) {
+		ba.clock = c
+	}
+}
+
+// WithDeferredStart prevents the actor from processing messages until Start is called. Messages sent in the meantime
+// are held in the mailbox. Slashie starts the actor once it has been added.
+func WithDeferredStart() BasicActorOpt {
+	return func(ba *BasicActor) {
+		ba.deferStart = true
+	}
+}
+
+func NewBasicActor(actorType Type, actorId Id, opts ...BasicActorOpt) *BasicActor {
+	stopCtx, stop := context.WithCancel(context.Background())
 	ba := &BasicActor{
 		actorType: actorType,
 		actorId:   actorId,
-		mailbox:   make(mailbox, DefaultMailBoxSize),
-		stopChan:  make(chan bool, 1),
+		stopCtx:   stopCtx,
+		stop:      stop,
 		wg:        sync.WaitGroup{},
-		handlers:  map[reflect.Type]Handler{},
 	}
+
+	for _, opt := range opts {
+		opt(ba)
+	}
+
+	// Set defaults
+	if ba.mailbox == nil {
+		ba.mailbox = NewBoundedMailbox(DefaultMailBoxSize, OverflowBlock)
+	}
+	if ba.logger == nil {
+		ba.logger = logger.NewNullOutputLogger()
+	}
+	if ba.clock == nil {
+		ba.clock = clock.NewRealClock()
+	}
+	ba.logger = logger.NewPrefixLogger(ba.logger, fmt.Sprintf("[%s] ", ba.GetKey()))
+
+	ba.mailbox.OnDrop(func(message any) {
+		ba.deadLetter(message, &MailboxFullError{ActorKey: ba.GetKey()})
+	})
+
 	// Bootstrap message handlers
-	ba.registerMessageHandler(messageType, ba.handleMessage)
+	ba.registerMessageHandler(TypeOf[Message](), ba.handleMessage)
+	ba.registerMessageHandler(TypeOf[request](), ba.handleRequest)
 
 	ba.wg.Add(1)
-	go ba.Init()
+	if !ba.deferStart {
+		ba.Start()
+	}
 
 	return ba
 }
 
+func (ba *BasicActor) Start() {
+	ba.startOnce.Do(func() {
+		go ba.Init()
+	})
+}
+
+func (ba *BasicActor) GetLogger() logger.Logger {
+	return ba.logger
+}
+
+// invoke calls the handler with the given message. If the actor has a PanicHandler, then any panic is recovered and
+// returned as a HandlerPanicError.
+func (ba *BasicActor) invoke(handler ReplyHandler, message any) (value any, err error) {
+	if ba.panicHandler != nil {
+		defer func() {
+			if r := recover(); r != nil {
+				ba.logger.Errorf("Recovered from panic while handling %T: %v", message, r)
+				ba.panicHandler(message, r)
+				err = &HandlerPanicError{ActorKey: ba.GetKey(), Value: r}
+			}
+		}()
+	}
+	return handler(message)
+}
+
 func (ba *BasicActor) handleMessage(message any) {
 	message.(Message)()
 }
 
+// handleRequest passes the message wrapped by a request to its handler, and sends the result back to the caller.
+func (ba *BasicActor) handleRequest(message any) {
+	req := message.(request)
+	envelope := EnvelopeOf(req.message)
+	handler, ok := ba.handlerFor(envelope.Message)
+	if !ok {
+		ba.deadLetter(req, ba.unsupportedMessageType(envelope.Message))
+		return
+	}
+	value, err := ba.handle(handler, envelope)
+	req.reply <- reply{value: value, err: err}
+}
+
 func (ba *BasicActor) Init() {
+	defer ba.drainMailbox()
+
 	for {
-		select {
-		case message := <-ba.mailbox:
-			messageType := reflect.TypeOf(message)
-			if handler, ok := ba.handlers[messageType]; ok {
-				handler(message)
+		// Stopping takes precedence over any messages which are still in the mailbox.
+		if ba.isStopped() {
+			return
+		}
+		message, err := ba.next()
+		if err != nil {
+			return
+		}
+		envelope := EnvelopeOf(message)
+		handler, ok := ba.handlerFor(envelope.Message)
+		if !ok {
+			ba.deadLetter(message, ba.unsupportedMessageType(envelope.Message))
+			continue
+		}
+		_, _ = ba.handle(handler, envelope)
+	}
+}
+
+// next returns the next message to process, taking messages from the control queue and the mailbox in the order in
+// which they were sent. An error is returned once the actor has stopped.
+func (ba *BasicActor) next() (any, error) {
+	for {
+		if ba.pending == nil && ba.mailbox.Len() > 0 {
+			if message, err := ba.mailbox.Dequeue(ba.stopCtx); err == nil {
+				ba.setPending(message)
 			}
-		case <-ba.stopChan:
+		}
+		if message, ok := ba.control.popBefore(ba.pending); ok {
+			return message, nil
+		}
+		if ba.pending != nil {
+			message := ba.pending.message
+			ba.pending = nil
+			return message, nil
+		}
+		if ba.isStopped() {
+			return nil, ba.stopCtx.Err()
+		}
+
+		// Wait for a message to arrive in either the mailbox or the control queue.
+		ctx, cancel := ba.control.waitContext(ba.stopCtx)
+		message, err := ba.mailbox.Dequeue(ctx)
+		cancel()
+		if err == nil {
+			ba.setPending(message)
+		}
+	}
+}
+
+// setPending holds a message taken from the mailbox until any Message closures sent before it have been processed.
+func (ba *BasicActor) setPending(message any) {
+	seq, ok := message.(sequenced)
+	if !ok {
+		seq = sequenced{message: message}
+	}
+	ba.pending = &seq
+}
+
+// handle passes the message in the envelope to the handler, making the envelope available through CurrentEnvelope
+// while the handler runs.
+func (ba *BasicActor) handle(handler ReplyHandler, envelope Envelope) (any, error) {
+	previous := ba.currentEnvelope
+	ba.currentEnvelope = envelope
+	defer func() {
+		ba.currentEnvelope = previous
+	}()
+
+	return ba.invoke(handler, envelope.Message)
+}
+
+func (ba *BasicActor) MailboxLen() int {
+	return ba.mailbox.Len() + ba.control.len()
+}
+
+func (ba *BasicActor) CurrentEnvelope() Envelope {
+	return ba.currentEnvelope
+}
+
+// drainMailbox passes any messages which remain in the mailbox once the actor has stopped to the dead letter handler.
+func (ba *BasicActor) drainMailbox() {
+	if ba.pending != nil {
+		ba.deadLetter(ba.pending.message, &ActorStoppedError{ActorKey: ba.GetKey()})
+		ba.pending = nil
+	}
+	for ba.mailbox.Len() > 0 {
+		message, err := ba.mailbox.Dequeue(context.Background())
+		if err != nil {
 			return
 		}
+		ba.deadLetter(message, &ActorStoppedError{ActorKey: ba.GetKey()})
+	}
+}
+
+func (ba *BasicActor) OnDeadLetter(handler DeadLetterHandler) {
+	ba.deadLetterMu.Lock()
+	defer ba.deadLetterMu.Unlock()
+
+	ba.deadLetterHandler = handler
+}
+
+// deadLetter passes a message which could not be delivered to the dead letter handler, if there is one. Messages
+// sent through Ask are unwrapped, and the caller is notified of the reason instead of waiting for a reply. Message
+// closures are internal to the actor, and are never dead letters.
+func (ba *BasicActor) deadLetter(message any, reason error) {
+	message = unsequenced(message)
+	if req, ok := message.(request); ok {
+		req.reply <- reply{err: reason}
+		message = req.message
+	}
+	message = EnvelopeOf(message).Message
+	if _, ok := message.(Message); ok {
+		return
+	}
+
+	ba.deadLetterMu.RLock()
+	handler := ba.deadLetterHandler
+	ba.deadLetterMu.RUnlock()
+
+	if handler != nil {
+		handler(DeadLetter{ActorKey: ba.GetKey(), Message: message, Reason: reason, Timestamp: ba.clock.Now()})
 	}
 }
 
+// DeadLetter passes a message which could not be delivered to the actor's dead letter handler, in the same way as the
+// actor's own dead letters. It allows types which embed BasicActor to report their own dead letters.
+func (ba *BasicActor) DeadLetter(message any, reason error) {
+	ba.deadLetter(message, reason)
+}
+
 func (ba *BasicActor) GetType() Type {
 	return ba.actorType
 }
@@ -69,45 +333,210 @@ func (ba *BasicActor) GetKey() Key {
 }
 
 func (ba *BasicActor) Notify(message Message) {
-	ba.mailbox <- message
+	// Messages sent to a stopped actor are dropped.
+	_ = ba.send(message)
+}
+
+// send will add the given Message closure to the control queue, which is never full. The closure is processed once the
+// messages added to the mailbox before it have been processed. An ActorStoppedError is returned if the actor has
+// stopped.
+func (ba *BasicActor) send(message Message) error {
+	if ba.isStopped() || !ba.control.push(message) {
+		return &ActorStoppedError{ActorKey: ba.GetKey()}
+	}
+	return nil
+}
+
+// enqueue will add the given message to the mailbox, waiting no longer than ctx allows if the mailbox is full. ctx
+// must be derived from stopCtx. A MailboxFullError is returned if ctx is done before there is room for the message.
+func (ba *BasicActor) enqueue(ctx context.Context, message any) error {
+	if ba.isStopped() {
+		return &ActorStoppedError{ActorKey: ba.GetKey()}
+	}
+	err := ba.mailbox.Enqueue(ctx, ba.control.sequence(message))
+	switch {
+	case err == nil:
+		return nil
+	case ba.isStopped():
+		return &ActorStoppedError{ActorKey: ba.GetKey()}
+	case errors.Is(err, ErrMailboxFull), ctx.Err() != nil:
+		return &MailboxFullError{ActorKey: ba.GetKey()}
+	default:
+		return err
+	}
+}
+
+// tryEnqueue will add the given message to the mailbox, returning a MailboxFullError if the message cannot be added
+// before the deadline. A zero deadline returns immediately.
+func (ba *BasicActor) tryEnqueue(deadline time.Time, message any) error {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if deadline.IsZero() {
+		ctx, cancel = context.WithCancel(ba.stopCtx)
+		cancel()
+	} else {
+		ctx, cancel = context.WithDeadline(ba.stopCtx, deadline)
+		defer cancel()
+	}
+	return ba.deadLetterIfStopped(message, ba.enqueue(ctx, message))
+}
+
+// deadLetterIfStopped passes the message to the dead letter handler if err is an ActorStoppedError, and returns err.
+func (ba *BasicActor) deadLetterIfStopped(message any, err error) error {
+	if errors.Is(err, ErrActorStopped) {
+		ba.deadLetter(message, err)
+	}
+	return err
+}
+
+// isStopped returns true if the actor has stopped processing messages.
+func (ba *BasicActor) isStopped() bool {
+	return ba.stopCtx.Err() != nil
 }
 
 func (ba *BasicActor) RegisterMessageHandler(messageType any, handler Handler) {
-	ba.mailbox <- Message(func() {
-		ba.registerMessageHandler(messageType, handler)
+	ba.RegisterReplyHandler(messageType, func(message any) (any, error) {
+		handler(message)
+		return nil, nil
 	})
 }
 
 func (ba *BasicActor) registerMessageHandler(messageType any, handler Handler) {
-	reflectType := reflect.TypeOf(messageType)
-	ba.handlers[reflectType] = handler
+	ba.registerReplyHandler(messageType, func(message any) (any, error) {
+		handler(message)
+		return nil, nil
+	})
+}
+
+func (ba *BasicActor) RegisterReplyHandler(messageType any, handler ReplyHandler) {
+	for i := len(ba.middleware) - 1; i >= 0; i-- {
+		handler = ba.middleware[i](handler)
+	}
+	_ = ba.send(Message(func() {
+		ba.registerReplyHandler(messageType, handler)
+	}))
+}
+
+func (ba *BasicActor) registerReplyHandler(messageType any, handler ReplyHandler) {
+	registered := registeredHandler{messageType: typeOfSample(messageType), handler: handler}
+	for i, h := range ba.handlers {
+		if h.messageType.key == registered.messageType.key {
+			ba.handlers[i] = registered
+			return
+		}
+	}
+
+	// Handlers for concrete types are placed ahead of those for interfaces, so that they take precedence.
+	position := len(ba.handlers)
+	for position > 0 && !registered.messageType.isInterface() && ba.handlers[position-1].messageType.isInterface() {
+		position--
+	}
+	ba.handlers = append(ba.handlers, registeredHandler{})
+	copy(ba.handlers[position+1:], ba.handlers[position:])
+	ba.handlers[position] = registered
+}
+
+// handlerFor returns the handler registered for the given message. The second return value is false if there is no
+// handler for the message.
+func (ba *BasicActor) handlerFor(message any) (ReplyHandler, bool) {
+	for _, h := range ba.handlers {
+		if h.messageType.accepts(message) {
+			return h.handler, true
+		}
+	}
+	return nil, false
+}
+
+func (ba *BasicActor) unsupportedMessageType(message any) error {
+	return &UnsupportedMessageTypeError{ActorKey: ba.GetKey(), MessageType: reflect.TypeOf(message)}
 }
 
 func (ba *BasicActor) SendMessage(message any) error {
-	errChan := make(chan error)
-	ba.mailbox <- Message(func() {
+	message = Seal(message)
+	errChan := make(chan error, 1)
+	err := ba.send(Message(func() {
 		defer close(errChan)
 
-		messageType := reflect.TypeOf(message)
-		if _, ok := ba.handlers[messageType]; !ok {
-			errChan <- fmt.Errorf("unknown message type: %s", messageType)
+		if _, ok := ba.handlerFor(message.(Envelope).Message); !ok {
+			errChan <- ba.unsupportedMessageType(message.(Envelope).Message)
 		}
+	}))
+	if err != nil {
+		ba.deadLetter(message, err)
+		return err
+	}
 
-	})
-
-	if err := <-errChan; err != nil {
+	select {
+	case err = <-errChan:
+		if err != nil {
+			ba.deadLetter(message, err)
+			return err
+		}
+	case <-ba.stopCtx.Done():
+		err = &ActorStoppedError{ActorKey: ba.GetKey()}
+		ba.deadLetter(message, err)
 		return err
 	}
-	ba.mailbox <- message
+	return ba.deadLetterIfStopped(message, ba.enqueue(ba.stopCtx, message))
+}
 
-	return nil
+func (ba *BasicActor) TryNotify(message Message) error {
+	return ba.send(message)
+}
+
+func (ba *BasicActor) TrySendMessage(message any) error {
+	return ba.tryEnqueue(time.Time{}, Seal(message))
+}
+
+func (ba *BasicActor) TrySendMessageUntil(deadline time.Time, message any) error {
+	return ba.tryEnqueue(deadline, Seal(message))
+}
+
+func (ba *BasicActor) Ask(ctx context.Context, message any) (any, error) {
+	// The request is enqueued until either the caller gives up or the actor stops.
+	enqueueCtx, cancel := context.WithCancel(ba.stopCtx)
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-enqueueCtx.Done():
+		}
+	}()
+
+	// The reply is buffered so that the actor is never blocked on a caller which has given up waiting.
+	replyChan := make(chan reply, 1)
+	message = Seal(message)
+	if err := ba.enqueue(enqueueCtx, request{message: message, reply: replyChan}); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, ba.deadLetterIfStopped(message, err)
+	}
+
+	select {
+	case r := <-replyChan:
+		return r.value, r.err
+	case <-ba.stopCtx.Done():
+		// The actor may have replied before stopping.
+		select {
+		case r := <-replyChan:
+			return r.value, r.err
+		default:
+			return nil, &ActorStoppedError{ActorKey: ba.GetKey()}
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 func (ba *BasicActor) Stop() {
-	ba.mailbox <- Message(func() {
-		ba.stopChan <- true
+	// The actor stops once the messages sent before Stop have been processed. Any Message closures sent after Stop are
+	// rejected, since the actor stops before it would process them.
+	ba.control.close(Message(func() {
+		ba.stop()
 		ba.wg.Done()
-	})
+	}))
 }
 
 func (ba *BasicActor) Wait() {
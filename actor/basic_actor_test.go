@@ -1,9 +1,13 @@
 package actor
 
 import (
+	"context"
+	"github.com/strategicpause/slashie/clock"
+	"github.com/strategicpause/slashie/logger"
 	"github.com/stretchr/testify/assert"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestGetType(t *testing.T) {
@@ -69,5 +73,413 @@ func TestBasicActor_SendUnknownMessage(t *testing.T) {
 
 	err := actor.SendMessage(testType{message: "TestMessage"})
 
-	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, ErrUnsupportedMessageType)
+}
+
+func TestBasicActor_SendMessageAfterStop(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+	actor.RegisterMessageHandler(testType{}, func(message any) {})
+	actor.Stop()
+	actor.Wait()
+
+	err := actor.SendMessage(testType{message: "TestMessage"})
+
+	var stoppedErr *ActorStoppedError
+	assert.ErrorAs(t, err, &stoppedErr)
+	assert.ErrorIs(t, err, ErrActorStopped)
+	assert.Equal(t, ActorKey, stoppedErr.ActorKey)
+}
+
+func TestBasicActor_Ask(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+	actor.RegisterReplyHandler(testType{}, func(message any) (any, error) {
+		return "Reply:" + message.(testType).message, nil
+	})
+	actor.RegisterMessageHandler("", func(message any) {})
+
+	reply, err := actor.Ask(context.Background(), testType{message: "TestMessage"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Reply:TestMessage", reply)
+
+	// Messages handled by a Handler reply with nil.
+	reply, err = actor.Ask(context.Background(), "TestMessage")
+	assert.NoError(t, err)
+	assert.Nil(t, reply)
+
+	_, err = actor.Ask(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrUnsupportedMessageType)
+}
+
+func TestBasicActor_AskTimeout(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+	unblock := make(chan bool)
+	actor.RegisterReplyHandler(testType{}, func(message any) (any, error) {
+		<-unblock
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := actor.Ask(ctx, testType{message: "TestMessage"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(unblock)
+}
+
+func TestBasicActor_AskAfterStop(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+	actor.RegisterReplyHandler(testType{}, func(message any) (any, error) {
+		return nil, nil
+	})
+	unblock := make(chan bool)
+	actor.Notify(func() {
+		<-unblock
+	})
+	actor.Stop()
+
+	// The request is sent after Stop, so the actor stops before replying.
+	errChan := make(chan error)
+	go func() {
+		_, err := actor.Ask(context.Background(), testType{message: "TestMessage"})
+		errChan <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(unblock)
+
+	assert.ErrorIs(t, <-errChan, ErrActorStopped)
+}
+
+func TestBasicActor_WithMailbox(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId, WithMailbox(NewBoundedMailbox(2, OverflowReject)))
+	actor.RegisterMessageHandler(testType{}, func(message any) {})
+
+	started := make(chan bool)
+	unblock := make(chan bool)
+	actor.Notify(func() {
+		started <- true
+		<-unblock
+	})
+	<-started
+
+	// The actor is blocked, so the mailbox fills up after two messages.
+	assert.NoError(t, actor.TrySendMessage(testType{message: "TestMessage"}))
+	assert.NoError(t, actor.TrySendMessage(testType{message: "TestMessage"}))
+	_, err := actor.Ask(context.Background(), testType{message: "TestMessage"})
+	var fullErr *MailboxFullError
+	assert.ErrorAs(t, err, &fullErr)
+	assert.ErrorIs(t, err, ErrMailboxFull)
+	assert.Equal(t, ActorKey, fullErr.ActorKey)
+
+	close(unblock)
+}
+
+// newFullActor returns an actor which is blocked until the returned channel is closed, and whose mailbox is full.
+func newFullActor(t *testing.T) (*BasicActor, chan bool) {
+	actor := NewBasicActor(ActorType, ActorId, WithMailboxSize(2))
+	actor.RegisterMessageHandler(testType{}, func(message any) {})
+
+	started := make(chan bool)
+	unblock := make(chan bool)
+	actor.Notify(func() {
+		started <- true
+		<-unblock
+	})
+	<-started
+
+	assert.NoError(t, actor.TrySendMessage(testType{message: "TestMessage"}))
+	assert.NoError(t, actor.TrySendMessage(testType{message: "TestMessage"}))
+
+	return actor, unblock
+}
+
+func TestBasicActor_TrySendMessage(t *testing.T) {
+	actor, unblock := newFullActor(t)
+
+	err := actor.TrySendMessage(testType{message: "TestMessage"})
+	assert.ErrorIs(t, err, ErrMailboxFull)
+	// Message closures are not stored in the mailbox.
+	assert.NoError(t, actor.TryNotify(func() {}))
+
+	close(unblock)
+	actor.Stop()
+	actor.Wait()
+	err = actor.TrySendMessage(testType{message: "TestMessage"})
+	assert.ErrorIs(t, err, ErrActorStopped)
+	err = actor.TryNotify(func() {})
+	assert.ErrorIs(t, err, ErrActorStopped)
+}
+
+func TestBasicActor_TryNotifyAfterStop(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+	unblock := make(chan bool)
+	actor.Notify(func() {
+		<-unblock
+	})
+
+	// The actor stops before it would process any Message closure sent after Stop.
+	actor.Stop()
+	err := actor.TryNotify(func() {})
+	assert.ErrorIs(t, err, ErrActorStopped)
+
+	close(unblock)
+	actor.Wait()
+}
+
+func TestBasicActor_ControlMessagesBypassOverflowPolicy(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowDropOldest, OverflowDropNewest, OverflowReject} {
+		actor := NewBasicActor(ActorType, ActorId, WithMailbox(NewBoundedMailbox(1, policy)))
+		actor.RegisterMessageHandler(testType{}, func(message any) {})
+
+		started := make(chan bool)
+		unblock := make(chan bool)
+		actor.Notify(func() {
+			started <- true
+			<-unblock
+		})
+		<-started
+		assert.NoError(t, actor.TrySendMessage(testType{message: "TestMessage"}))
+
+		// Neither the handler registration nor the type check made by SendMessage is dropped by the full mailbox.
+		received := make(chan string, 1)
+		actor.RegisterMessageHandler("", func(message any) {
+			received <- message.(string)
+		})
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- actor.SendMessage("TestMessage")
+		}()
+		time.Sleep(10 * time.Millisecond)
+		close(unblock)
+		assert.NoError(t, <-errChan)
+		assert.Equal(t, "TestMessage", <-received)
+
+		actor.Stop()
+		actor.Wait()
+	}
+}
+
+func TestBasicActor_MessagesProcessedInOrder(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+	deadLetters := make(chan DeadLetter, 3)
+	actor.OnDeadLetter(func(deadLetter DeadLetter) {
+		deadLetters <- deadLetter
+	})
+	var processed []string
+	actor.RegisterMessageHandler(testType{}, func(message any) {
+		processed = append(processed, message.(testType).message)
+	})
+
+	started := make(chan bool)
+	unblock := make(chan bool)
+	actor.Notify(func() {
+		started <- true
+		<-unblock
+	})
+	<-started
+
+	// Message closures are processed after the messages sent before them, including Stop.
+	assert.NoError(t, actor.TrySendMessage(testType{message: "a"}))
+	actor.Notify(func() {
+		processed = append(processed, "closure")
+	})
+	assert.NoError(t, actor.TrySendMessage(testType{message: "b"}))
+	assert.NoError(t, actor.TrySendMessage(testType{message: "c"}))
+	actor.Stop()
+	close(unblock)
+	actor.Wait()
+
+	assert.Equal(t, []string{"a", "closure", "b", "c"}, processed)
+	assert.Empty(t, deadLetters)
+}
+
+func TestPriorityMailbox_UserMessages(t *testing.T) {
+	var compared []any
+	actor := NewBasicActor(ActorType, ActorId, WithMailbox(NewPriorityMailbox(func(a any, b any) bool {
+		compared = append(compared, a, b)
+		return a.(testType).message < b.(testType).message
+	})))
+	processed := make(chan string, 3)
+	actor.RegisterMessageHandler(testType{}, func(message any) {
+		processed <- message.(testType).message
+	})
+
+	started := make(chan bool)
+	unblock := make(chan bool)
+	actor.Notify(func() {
+		started <- true
+		<-unblock
+	})
+	<-started
+	assert.NoError(t, actor.TrySendMessage(testType{message: "c"}))
+	go func() {
+		_, _ = actor.Ask(context.Background(), testType{message: "b"})
+	}()
+	assert.NoError(t, actor.TrySendMessage(testType{message: "a"}))
+	time.Sleep(10 * time.Millisecond)
+	close(unblock)
+
+	assert.Equal(t, "a", <-processed)
+	assert.Equal(t, "b", <-processed)
+	assert.Equal(t, "c", <-processed)
+	actor.Stop()
+	actor.Wait()
+	for _, message := range compared {
+		assert.IsType(t, testType{}, message)
+	}
+}
+
+func TestBasicActor_TrySendMessageUntil(t *testing.T) {
+	actor, unblock := newFullActor(t)
+
+	err := actor.TrySendMessageUntil(time.Now().Add(10*time.Millisecond), testType{message: "TestMessage"})
+	assert.ErrorIs(t, err, ErrMailboxFull)
+
+	// Room is made in the mailbox before the deadline.
+	time.AfterFunc(10*time.Millisecond, func() {
+		close(unblock)
+	})
+	err = actor.TrySendMessageUntil(time.Now().Add(time.Minute), testType{message: "TestMessage"})
+	assert.NoError(t, err)
+}
+
+func TestBasicActor_OnDeadLetter(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	actor := NewBasicActor(ActorType, ActorId, WithMailbox(NewBoundedMailbox(1, OverflowDropOldest)), WithClock(c))
+	deadLetters := make(chan DeadLetter, 10)
+	actor.OnDeadLetter(func(deadLetter DeadLetter) {
+		deadLetters <- deadLetter
+	})
+
+	started := make(chan bool)
+	unblock := make(chan bool)
+	actor.Notify(func() {
+		started <- true
+		<-unblock
+	})
+	<-started
+
+	// The oldest message is dropped to make room for the newest message.
+	assert.NoError(t, actor.TrySendMessage(testType{message: "oldest"}))
+	assert.NoError(t, actor.TrySendMessage(testType{message: "newest"}))
+	deadLetter := <-deadLetters
+	assert.Equal(t, ActorKey, deadLetter.ActorKey)
+	assert.Equal(t, testType{message: "oldest"}, deadLetter.Message)
+	assert.ErrorIs(t, deadLetter.Reason, ErrMailboxFull)
+	assert.Equal(t, c.Now(), deadLetter.Timestamp)
+
+	// The newest message has no handler.
+	close(unblock)
+	deadLetter = <-deadLetters
+	assert.Equal(t, testType{message: "newest"}, deadLetter.Message)
+	assert.ErrorIs(t, deadLetter.Reason, ErrUnsupportedMessageType)
+
+	// Messages sent once the actor has stopped are dead letters, unlike Message closures, which are internal to the
+	// actor.
+	actor.Stop()
+	actor.Wait()
+	actor.Notify(func() {})
+	err := actor.TrySendMessage(testType{message: "stopped"})
+	assert.ErrorIs(t, err, ErrActorStopped)
+	deadLetter = <-deadLetters
+	assert.Equal(t, testType{message: "stopped"}, deadLetter.Message)
+	assert.ErrorIs(t, deadLetter.Reason, ErrActorStopped)
+	assert.Empty(t, deadLetters)
+}
+
+type testLogger struct {
+	logger.Logger
+	messages chan string
+}
+
+func (l *testLogger) Error(message string) {
+	l.messages <- message
+}
+
+func TestBasicActor_WithPanicHandler(t *testing.T) {
+	l := &testLogger{Logger: logger.NewNullOutputLogger(), messages: make(chan string, 1)}
+	recovered := make(chan any, 1)
+	actor := NewBasicActor(ActorType, ActorId, WithLogger(l), WithPanicHandler(func(message any, r any) {
+		recovered <- r
+	}))
+	actor.RegisterReplyHandler(testType{}, func(message any) (any, error) {
+		panic("TestPanic")
+	})
+
+	_, err := actor.Ask(context.Background(), testType{message: "TestMessage"})
+	assert.ErrorIs(t, err, ErrHandlerPanic)
+	assert.Equal(t, "TestPanic", <-recovered)
+	assert.Equal(t, "["+string(ActorKey)+"] Recovered from panic while handling actor.testType: TestPanic", <-l.messages)
+
+	// The actor continues to process messages.
+	processed := make(chan bool)
+	actor.Notify(func() {
+		processed <- true
+	})
+	assert.True(t, <-processed)
+}
+
+func TestBasicActor_WithMiddleware(t *testing.T) {
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(next ReplyHandler) ReplyHandler {
+			return func(message any) (any, error) {
+				calls = append(calls, name)
+				return next(message)
+			}
+		}
+	}
+	actor := NewBasicActor(ActorType, ActorId, WithMiddleware(middleware("outer"), middleware("inner")))
+	actor.RegisterMessageHandler(testType{}, func(message any) {
+		calls = append(calls, "handler")
+	})
+
+	_, err := actor.Ask(context.Background(), testType{message: "TestMessage"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner", "handler"}, calls)
+}
+
+func TestBasicActor_WithDeferredStart(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId, WithDeferredStart())
+
+	processed := make(chan bool, 1)
+	actor.Notify(func() {
+		processed <- true
+	})
+	select {
+	case <-processed:
+		assert.Fail(t, "actor should not process messages before it is started")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	actor.Start()
+	actor.Start()
+	assert.True(t, <-processed)
+}
+
+func TestBasicActor_CurrentEnvelope(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+	envelopes := make(chan Envelope, 2)
+	actor.RegisterMessageHandler(testType{}, func(message any) {
+		envelopes <- actor.CurrentEnvelope()
+	})
+
+	// Envelopes are unwrapped before the message is passed to the handler.
+	sent := Envelope{Sender: "Actor:Sender", Message: testType{message: "TestMessage"}}.WithHeader("Key", "Value")
+	err := actor.SendMessage(sent)
+	assert.NoError(t, err)
+	envelope := <-envelopes
+	assert.Equal(t, sent.Sender, envelope.Sender)
+	assert.Equal(t, sent.Message, envelope.Message)
+	assert.Equal(t, "Value", envelope.Header("Key"))
+	assert.NotEmpty(t, envelope.Id)
+	assert.Equal(t, envelope.Id, envelope.CorrelationId)
+	assert.False(t, envelope.CreatedAt.IsZero())
+
+	// Other messages are wrapped in an Envelope when they are sent.
+	err = actor.SendMessage(testType{message: "TestMessage"})
+	assert.NoError(t, err)
+	next := <-envelopes
+	assert.Equal(t, testType{message: "TestMessage"}, next.Message)
+	assert.NotEmpty(t, next.Id)
+	assert.NotEqual(t, envelope.Id, next.Id)
 }
@@ -0,0 +1,105 @@
+package actor
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// messageIdPrefix distinguishes the message Ids generated by different processes.
+	messageIdPrefix = newMessageIdPrefix()
+	// messageIdCounter is incremented for each message Id generated by this process.
+	messageIdCounter uint64
+)
+
+// Envelope wraps a message with information about where it came from. Actors unwrap envelopes before dispatching the
+// message to its handler, which can access the envelope through CurrentEnvelope.
+type Envelope struct {
+	// Id uniquely identifies the message.
+	Id string
+	// CorrelationId is shared by each message which follows from the same original message.
+	CorrelationId string
+	// CausationId is the Id of the message whose handler sent this message, if any.
+	CausationId string
+	// Sender identifies the actor which sent the message, if any.
+	Sender    Key
+	CreatedAt time.Time
+	// Headers holds free-form metadata, which is propagated to follow-up messages.
+	Headers map[string]string
+	Message any
+}
+
+// Seal returns the given message as an Envelope, wrapping it if necessary, and assigns any of the Id, CorrelationId
+// & CreatedAt which have not been set. A new Id is also used as the CorrelationId. Actors seal each message sent
+// through SendMessage, TrySendMessage & Ask.
+func Seal(message any) Envelope {
+	envelope := EnvelopeOf(message)
+	if envelope.Id == "" {
+		envelope.Id = newMessageId()
+	}
+	if envelope.CorrelationId == "" {
+		envelope.CorrelationId = envelope.Id
+	}
+	if envelope.CreatedAt.IsZero() {
+		envelope.CreatedAt = time.Now()
+	}
+	return envelope
+}
+
+// EnvelopeOf returns the given message if it is an Envelope, or otherwise wraps it in an empty Envelope.
+func EnvelopeOf(message any) Envelope {
+	if envelope, ok := message.(Envelope); ok {
+		return envelope
+	}
+	return Envelope{Message: message}
+}
+
+// FollowUp returns an Envelope for a message which is sent by the given actor while handling the message in this
+// Envelope. The CorrelationId and Headers are propagated, and the CausationId is set to the Id of this Envelope.
+func (e Envelope) FollowUp(sender Key, message any) Envelope {
+	return Envelope{
+		CorrelationId: e.CorrelationId,
+		CausationId:   e.Id,
+		Sender:        sender,
+		Headers:       e.copyHeaders(),
+		Message:       message,
+	}
+}
+
+// WithHeader returns a copy of the Envelope with the given header set.
+func (e Envelope) WithHeader(key string, value string) Envelope {
+	e.Headers = e.copyHeaders()
+	e.Headers[key] = value
+	return e
+}
+
+// Header returns the value of the given header, or an empty string if it has not been set.
+func (e Envelope) Header(key string) string {
+	return e.Headers[key]
+}
+
+// copyHeaders returns a copy of the headers, so that envelopes handled by different actors never share a map.
+func (e Envelope) copyHeaders() map[string]string {
+	headers := make(map[string]string, len(e.Headers))
+	for key, value := range e.Headers {
+		headers[key] = value
+	}
+	return headers
+}
+
+// newMessageId returns an Id which is unique across processes.
+func newMessageId() string {
+	return fmt.Sprintf("%s-%d", messageIdPrefix, atomic.AddUint64(&messageIdCounter, 1))
+}
+
+func newMessageIdPrefix() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		// Ids remain unique within the process.
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
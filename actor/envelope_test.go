@@ -0,0 +1,45 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeal(t *testing.T) {
+	envelope := Seal(testType{message: "TestMessage"})
+
+	assert.Equal(t, testType{message: "TestMessage"}, envelope.Message)
+	assert.NotEmpty(t, envelope.Id)
+	assert.Equal(t, envelope.Id, envelope.CorrelationId)
+	assert.Empty(t, envelope.CausationId)
+	assert.False(t, envelope.CreatedAt.IsZero())
+
+	// Fields which have already been set are kept.
+	createdAt := time.Unix(0, 0)
+	sealed := Seal(Envelope{Id: "Id", CorrelationId: "CorrelationId", CreatedAt: createdAt})
+	assert.Equal(t, "Id", sealed.Id)
+	assert.Equal(t, "CorrelationId", sealed.CorrelationId)
+	assert.Equal(t, createdAt, sealed.CreatedAt)
+
+	// Sealing an Envelope again does not change it.
+	assert.Equal(t, envelope, Seal(envelope))
+}
+
+func TestEnvelope_FollowUp(t *testing.T) {
+	envelope := Seal(testType{message: "TestMessage"}).WithHeader("Key", "Value")
+
+	followUp := Seal(envelope.FollowUp(ActorKey, "FollowUp"))
+	assert.Equal(t, "FollowUp", followUp.Message)
+	assert.Equal(t, ActorKey, followUp.Sender)
+	assert.Equal(t, envelope.CorrelationId, followUp.CorrelationId)
+	assert.Equal(t, envelope.Id, followUp.CausationId)
+	assert.NotEqual(t, envelope.Id, followUp.Id)
+	assert.Equal(t, "Value", followUp.Header("Key"))
+
+	// Headers are copied, so changes to the follow-up are not visible to the original envelope.
+	followUp = followUp.WithHeader("Key", "Changed")
+	assert.Equal(t, "Value", envelope.Header("Key"))
+	assert.Equal(t, "Changed", followUp.Header("Key"))
+}
@@ -0,0 +1,238 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrUnknownActor is returned when an operation references an actor which has not been registered.
+	ErrUnknownActor = errors.New("unknown actor")
+	// ErrIllegalTransition is returned when an actor cannot transition between two statuses.
+	ErrIllegalTransition = errors.New("illegal transition")
+	// ErrTransitionRejected is returned when a guard prevents an actor from transitioning between two statuses.
+	ErrTransitionRejected = errors.New("transition rejected")
+	// ErrActorBusy is returned when a status update is rejected because the actor is already transitioning.
+	ErrActorBusy = errors.New("actor busy")
+	// ErrNotTransitioning is returned when cancelling a transition for an actor which is not transitioning.
+	ErrNotTransitioning = errors.New("not transitioning")
+	// ErrInvalidSubscriptionStatus is returned when subscribing to a status which an actor cannot transition to.
+	ErrInvalidSubscriptionStatus = errors.New("invalid subscription status")
+	// ErrUnsupportedMessageType is returned when an actor has no Handler registered for a message type.
+	ErrUnsupportedMessageType = errors.New("unsupported message type")
+	// ErrInvalidStatusHierarchy is returned when nesting a status would result in an invalid status hierarchy.
+	ErrInvalidStatusHierarchy = errors.New("invalid status hierarchy")
+	// ErrUnexpectedReplyType is returned when an actor replies to Ask with a value of the wrong type.
+	ErrUnexpectedReplyType = errors.New("unexpected reply type")
+	// ErrMailboxFull is returned when a message cannot be added to an actor's mailbox because it is full.
+	ErrMailboxFull = errors.New("mailbox full")
+	// ErrCoordinatorBusy is returned when the slashie coordinator cannot look up an actor in time.
+	ErrCoordinatorBusy = errors.New("coordinator busy")
+	// ErrHandlerPanic is returned when a handler panics while handling a message.
+	ErrHandlerPanic = errors.New("handler panic")
+	// ErrActorStopped is returned when an actor can no longer process messages because it has been stopped.
+	ErrActorStopped = errors.New("actor stopped")
+	// ErrInvalidKeyPattern is returned when looking up actors with a malformed Key pattern.
+	ErrInvalidKeyPattern = errors.New("invalid key pattern")
+)
+
+// UnknownActorError indicates that the actor identified by ActorKey has not been registered.
+type UnknownActorError struct {
+	ActorKey Key
+}
+
+func (e *UnknownActorError) Error() string {
+	return fmt.Sprintf("unknown actor %s", e.ActorKey)
+}
+
+func (e *UnknownActorError) Is(target error) bool {
+	return target == ErrUnknownActor
+}
+
+// IllegalTransitionError indicates that the actor identified by ActorKey cannot transition from SrcStatus to
+// DestStatus.
+type IllegalTransitionError struct {
+	ActorKey   Key
+	SrcStatus  Status
+	DestStatus Status
+}
+
+func (e *IllegalTransitionError) Error() string {
+	return fmt.Sprintf("transitioning from %s to %s is an illegal transition for actor %s", e.SrcStatus, e.DestStatus, e.ActorKey)
+}
+
+func (e *IllegalTransitionError) Is(target error) bool {
+	return target == ErrIllegalTransition
+}
+
+// TransitionRejectedError indicates that a guard prevented the actor identified by ActorKey from transitioning from
+// SrcStatus to DestStatus.
+type TransitionRejectedError struct {
+	ActorKey   Key
+	SrcStatus  Status
+	DestStatus Status
+}
+
+func (e *TransitionRejectedError) Error() string {
+	return fmt.Sprintf("transitioning from %s to %s was rejected by a guard for actor %s", e.SrcStatus, e.DestStatus, e.ActorKey)
+}
+
+func (e *TransitionRejectedError) Is(target error) bool {
+	return target == ErrTransitionRejected
+}
+
+// ActorBusyError indicates that the actor identified by ActorKey could not accept a status update to Status since
+// it is already transitioning.
+type ActorBusyError struct {
+	ActorKey Key
+	Status   Status
+}
+
+func (e *ActorBusyError) Error() string {
+	return fmt.Sprintf("cannot update status to %s while actor %s is transitioning", e.Status, e.ActorKey)
+}
+
+func (e *ActorBusyError) Is(target error) bool {
+	return target == ErrActorBusy
+}
+
+// NotTransitioningError indicates that the actor identified by ActorKey is not transitioning.
+type NotTransitioningError struct {
+	ActorKey Key
+	Status   Status
+}
+
+func (e *NotTransitioningError) Error() string {
+	return fmt.Sprintf("actor %s is not transitioning from %s", e.ActorKey, e.Status)
+}
+
+func (e *NotTransitioningError) Is(target error) bool {
+	return target == ErrNotTransitioning
+}
+
+// InvalidSubscriptionStatusError indicates that a subscription cannot be added for the actor identified by ActorKey
+// because it is either in, or has already passed, the given Status.
+type InvalidSubscriptionStatusError struct {
+	ActorKey Key
+	Status   Status
+}
+
+func (e *InvalidSubscriptionStatusError) Error() string {
+	return fmt.Sprintf("cannot subscribe to status %s for actor %s", e.Status, e.ActorKey)
+}
+
+func (e *InvalidSubscriptionStatusError) Is(target error) bool {
+	return target == ErrInvalidSubscriptionStatus
+}
+
+// UnsupportedMessageTypeError indicates that the actor identified by ActorKey has no Handler for MessageType.
+type UnsupportedMessageTypeError struct {
+	ActorKey    Key
+	MessageType reflect.Type
+}
+
+func (e *UnsupportedMessageTypeError) Error() string {
+	return fmt.Sprintf("unknown message type %s for actor %s", e.MessageType, e.ActorKey)
+}
+
+func (e *UnsupportedMessageTypeError) Is(target error) bool {
+	return target == ErrUnsupportedMessageType
+}
+
+// InvalidStatusHierarchyError indicates that ChildStatus cannot be nested under ParentStatus for the actor identified
+// by ActorKey, either because ChildStatus already has a parent, or because ChildStatus is an ancestor of ParentStatus.
+type InvalidStatusHierarchyError struct {
+	ActorKey     Key
+	ParentStatus Status
+	ChildStatus  Status
+}
+
+func (e *InvalidStatusHierarchyError) Error() string {
+	return fmt.Sprintf("cannot nest status %s under %s for actor %s", e.ChildStatus, e.ParentStatus, e.ActorKey)
+}
+
+func (e *InvalidStatusHierarchyError) Is(target error) bool {
+	return target == ErrInvalidStatusHierarchy
+}
+
+// ActorStoppedError indicates that the actor identified by ActorKey has been stopped.
+type ActorStoppedError struct {
+	ActorKey Key
+}
+
+func (e *ActorStoppedError) Error() string {
+	return fmt.Sprintf("actor %s has stopped", e.ActorKey)
+}
+
+func (e *ActorStoppedError) Is(target error) bool {
+	return target == ErrActorStopped
+}
+
+// UnexpectedReplyTypeError indicates that the actor identified by ActorKey replied with a value of an unexpected type.
+type UnexpectedReplyTypeError struct {
+	ActorKey Key
+	Reply    any
+}
+
+func (e *UnexpectedReplyTypeError) Error() string {
+	return fmt.Sprintf("unexpected reply type %T from actor %s", e.Reply, e.ActorKey)
+}
+
+func (e *UnexpectedReplyTypeError) Is(target error) bool {
+	return target == ErrUnexpectedReplyType
+}
+
+// MailboxFullError indicates that the mailbox for the actor identified by ActorKey is full.
+type MailboxFullError struct {
+	ActorKey Key
+}
+
+func (e *MailboxFullError) Error() string {
+	return fmt.Sprintf("mailbox for actor %s is full", e.ActorKey)
+}
+
+func (e *MailboxFullError) Is(target error) bool {
+	return target == ErrMailboxFull
+}
+
+// CoordinatorBusyError indicates that the slashie coordinator could not look up the actor identified by ActorKey
+// before the deadline, because it is busy with other messages.
+type CoordinatorBusyError struct {
+	ActorKey Key
+}
+
+func (e *CoordinatorBusyError) Error() string {
+	return fmt.Sprintf("slashie coordinator is too busy to look up actor %s", e.ActorKey)
+}
+
+func (e *CoordinatorBusyError) Is(target error) bool {
+	return target == ErrCoordinatorBusy
+}
+
+// HandlerPanicError indicates that a handler for the actor identified by ActorKey panicked with Value.
+type HandlerPanicError struct {
+	ActorKey Key
+	Value    any
+}
+
+func (e *HandlerPanicError) Error() string {
+	return fmt.Sprintf("handler for actor %s panicked: %v", e.ActorKey, e.Value)
+}
+
+func (e *HandlerPanicError) Is(target error) bool {
+	return target == ErrHandlerPanic
+}
+
+// InvalidKeyPatternError indicates that Pattern is not a valid glob pattern.
+type InvalidKeyPatternError struct {
+	Pattern string
+}
+
+func (e *InvalidKeyPatternError) Error() string {
+	return fmt.Sprintf("invalid key pattern %q", e.Pattern)
+}
+
+func (e *InvalidKeyPatternError) Is(target error) bool {
+	return target == ErrInvalidKeyPattern
+}
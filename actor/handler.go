@@ -0,0 +1,36 @@
+package actor
+
+import (
+	"context"
+)
+
+// Handle registers a handler for messages of type T with the given actor. If T is an interface, then the handler
+// receives any message which implements T, unless a handler is registered for the message's concrete type.
+func Handle[T any](a Actor, handler func(message T)) {
+	a.RegisterMessageHandler(TypeOf[T](), func(message any) {
+		handler(message.(T))
+	})
+}
+
+// HandleReply registers a handler for messages of type T with the given actor, which replies with a value of type R
+// to callers of Ask. T may be an interface, as with Handle.
+func HandleReply[T any, R any](a Actor, handler func(message T) (R, error)) {
+	a.RegisterReplyHandler(TypeOf[T](), func(message any) (any, error) {
+		return handler(message.(T))
+	})
+}
+
+// Ask sends a message of type T to the given actor, and waits for a reply of type R. A nil reply is returned as the
+// zero value of R. An UnexpectedReplyTypeError is returned if the reply is not of type R.
+func Ask[T any, R any](ctx context.Context, a Actor, message T) (R, error) {
+	var zero R
+	reply, err := a.Ask(ctx, message)
+	if err != nil || reply == nil {
+		return zero, err
+	}
+	r, ok := reply.(R)
+	if !ok {
+		return zero, &UnexpectedReplyTypeError{ActorKey: a.GetKey(), Reply: reply}
+	}
+	return r, nil
+}
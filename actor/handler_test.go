@@ -0,0 +1,80 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testReply struct {
+	message string
+}
+
+func TestHandle(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	var received testType
+	Handle(actor, func(message testType) {
+		received = message
+		wg.Done()
+	})
+
+	err := actor.SendMessage(testType{message: "TestMessage"})
+	assert.NoError(t, err)
+
+	wg.Wait()
+	assert.Equal(t, "TestMessage", received.message)
+}
+
+func TestAsk(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+	HandleReply(actor, func(message testType) (testReply, error) {
+		return testReply{message: "Reply:" + message.message}, nil
+	})
+	Handle(actor, func(message string) {})
+
+	reply, err := Ask[testType, testReply](context.Background(), actor, testType{message: "TestMessage"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Reply:TestMessage", reply.message)
+
+	// A nil reply is returned as the zero value.
+	reply, err = Ask[string, testReply](context.Background(), actor, "TestMessage")
+	assert.NoError(t, err)
+	assert.Equal(t, testReply{}, reply)
+
+	_, err = Ask[testType, string](context.Background(), actor, testType{message: "TestMessage"})
+	var replyErr *UnexpectedReplyTypeError
+	assert.ErrorAs(t, err, &replyErr)
+	assert.ErrorIs(t, err, ErrUnexpectedReplyType)
+	assert.Equal(t, testReply{message: "Reply:TestMessage"}, replyErr.Reply)
+}
+
+func TestHandle_Interface(t *testing.T) {
+	actor := NewBasicActor(ActorType, ActorId)
+
+	// The handler for the interface receives messages which have no handler for their concrete type, regardless of
+	// the order in which the handlers were registered.
+	HandleReply(actor, func(message fmt.Stringer) (string, error) {
+		return "Stringer:" + message.String(), nil
+	})
+	HandleReply(actor, func(message time.Month) (string, error) {
+		return "Month:" + message.String(), nil
+	})
+
+	reply, err := Ask[time.Weekday, string](context.Background(), actor, time.Monday)
+	assert.NoError(t, err)
+	assert.Equal(t, "Stringer:Monday", reply)
+	reply, err = Ask[time.Month, string](context.Background(), actor, time.January)
+	assert.NoError(t, err)
+	assert.Equal(t, "Month:January", reply)
+
+	err = actor.SendMessage(testType{message: "TestMessage"})
+	assert.ErrorIs(t, err, ErrUnsupportedMessageType)
+}
@@ -0,0 +1,396 @@
+package actor
+
+import (
+	"container/heap"
+	"container/list"
+	"context"
+	"sync"
+)
+
+// Mailbox stores the messages which are waiting to be processed by an actor. Implementations must support
+// concurrent producers, and a single consumer. Only messages sent through SendMessage, TrySendMessage & Ask are
+// stored in the Mailbox, since the actor keeps its own Message closures in a separate queue which is never full. Each
+// Message closure is processed after the messages which were added to the Mailbox before it.
+type Mailbox interface {
+	// Enqueue adds a message to the mailbox. If the mailbox is full, then depending on its OverflowPolicy, Enqueue
+	// either blocks until there is room or ctx is done, drops a message, or returns ErrMailboxFull.
+	Enqueue(ctx context.Context, message any) error
+	// Dequeue removes the next message from the mailbox, blocking until one is available or ctx is done.
+	Dequeue(ctx context.Context) (any, error)
+	// Len returns the number of messages in the mailbox.
+	Len() int
+	// OnDrop sets the handler which is called with each message that the mailbox drops.
+	OnDrop(handler func(message any))
+}
+
+// MailboxLen returns the number of messages waiting in the given actor's Mailbox, or 0 if the actor does not
+// implement MailboxLenReporter.
+func MailboxLen(a Actor) int {
+	if reporter, ok := a.(MailboxLenReporter); ok {
+		return reporter.MailboxLen()
+	}
+	return 0
+}
+
+// OverflowPolicy determines how a bounded mailbox handles messages once it is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the sender until there is room in the mailbox.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest message in the mailbox to make room for the new message.
+	OverflowDropOldest
+	// OverflowDropNewest drops the new message.
+	OverflowDropNewest
+	// OverflowReject returns ErrMailboxFull to the sender.
+	OverflowReject
+)
+
+// boundedMailbox is a Mailbox backed by a buffered channel.
+type boundedMailbox struct {
+	messages chan any
+	policy   OverflowPolicy
+	onDrop   func(message any)
+}
+
+// NewBoundedMailbox returns a Mailbox which holds up to size messages, and handles any further messages according
+// to the given OverflowPolicy.
+func NewBoundedMailbox(size int, policy OverflowPolicy) Mailbox {
+	return &boundedMailbox{
+		messages: make(chan any, size),
+		policy:   policy,
+	}
+}
+
+func (m *boundedMailbox) Enqueue(ctx context.Context, message any) error {
+	// Always attempt to add the message first, so that a done context only matters when the mailbox is full.
+	select {
+	case m.messages <- message:
+		return nil
+	default:
+	}
+
+	switch m.policy {
+	case OverflowDropOldest:
+		for {
+			select {
+			case m.messages <- message:
+				return nil
+			default:
+			}
+			select {
+			case dropped := <-m.messages:
+				m.drop(dropped)
+			default:
+			}
+		}
+	case OverflowDropNewest:
+		m.drop(message)
+		return nil
+	case OverflowReject:
+		return ErrMailboxFull
+	default:
+		select {
+		case m.messages <- message:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *boundedMailbox) Dequeue(ctx context.Context) (any, error) {
+	select {
+	case message := <-m.messages:
+		return message, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (m *boundedMailbox) Len() int {
+	return len(m.messages)
+}
+
+func (m *boundedMailbox) OnDrop(handler func(message any)) {
+	m.onDrop = handler
+}
+
+// drop passes the given message to the drop handler, if there is one.
+func (m *boundedMailbox) drop(message any) {
+	if m.onDrop != nil {
+		m.onDrop(message)
+	}
+}
+
+// queue is the underlying storage for an unboundedMailbox.
+type queue interface {
+	push(message any)
+	pop() any
+	len() int
+}
+
+// unboundedMailbox is a Mailbox which never blocks the sender.
+type unboundedMailbox struct {
+	mu       sync.Mutex
+	messages queue
+	// notEmpty is signalled each time a message is added to the mailbox.
+	notEmpty chan struct{}
+}
+
+// NewUnboundedMailbox returns a Mailbox with no limit on the number of messages. Messages are processed in the order
+// in which they were received.
+func NewUnboundedMailbox() Mailbox {
+	return &unboundedMailbox{
+		messages: &fifoQueue{list: list.New()},
+		notEmpty: make(chan struct{}, 1),
+	}
+}
+
+// NewPriorityMailbox returns a Mailbox with no limit on the number of messages. Messages are processed in the order
+// determined by less, where less returns true if message a should be processed before message b. Messages of equal
+// priority are processed in the order in which they were received. less is called with the messages as they were
+// sent, without any Envelope. This includes the Message closures sent by the Snapshot & Reset methods of a
+// StatefulActor.
+func NewPriorityMailbox(less func(a any, b any) bool) Mailbox {
+	return &unboundedMailbox{
+		messages: &priorityQueue{less: func(a any, b any) bool {
+			return less(userMessage(a), userMessage(b))
+		}},
+		notEmpty: make(chan struct{}, 1),
+	}
+}
+
+func (m *unboundedMailbox) Enqueue(_ context.Context, message any) error {
+	m.mu.Lock()
+	m.messages.push(message)
+	m.mu.Unlock()
+
+	select {
+	case m.notEmpty <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (m *unboundedMailbox) Dequeue(ctx context.Context) (any, error) {
+	for {
+		m.mu.Lock()
+		if m.messages.len() > 0 {
+			message := m.messages.pop()
+			m.mu.Unlock()
+			return message, nil
+		}
+		m.mu.Unlock()
+
+		select {
+		case <-m.notEmpty:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (m *unboundedMailbox) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.messages.len()
+}
+
+// OnDrop is a no-op, since an unbounded mailbox never drops messages.
+func (m *unboundedMailbox) OnDrop(_ func(message any)) {}
+
+// fifoQueue is a queue backed by a linked list.
+type fifoQueue struct {
+	list *list.List
+}
+
+func (q *fifoQueue) push(message any) {
+	q.list.PushBack(message)
+}
+
+func (q *fifoQueue) pop() any {
+	return q.list.Remove(q.list.Front())
+}
+
+func (q *fifoQueue) len() int {
+	return q.list.Len()
+}
+
+// priorityQueue is a queue backed by a heap.
+type priorityQueue struct {
+	less  func(a any, b any) bool
+	items []*priorityItem
+	// seq is used to order messages of equal priority.
+	seq uint64
+}
+
+type priorityItem struct {
+	message any
+	seq     uint64
+}
+
+func (q *priorityQueue) push(message any) {
+	q.seq += 1
+	heap.Push(q, &priorityItem{message: message, seq: q.seq})
+}
+
+func (q *priorityQueue) pop() any {
+	return heap.Pop(q).(*priorityItem).message
+}
+
+func (q *priorityQueue) len() int {
+	return len(q.items)
+}
+
+// The following methods implement heap.Interface, and should only be called through the heap package.
+
+func (q *priorityQueue) Len() int {
+	return len(q.items)
+}
+
+func (q *priorityQueue) Less(i, j int) bool {
+	a, b := q.items[i], q.items[j]
+	if q.less(a.message, b.message) {
+		return true
+	}
+	if q.less(b.message, a.message) {
+		return false
+	}
+	return a.seq < b.seq
+}
+
+func (q *priorityQueue) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+}
+
+func (q *priorityQueue) Push(x any) {
+	q.items = append(q.items, x.(*priorityItem))
+}
+
+func (q *priorityQueue) Pop() any {
+	old := q.items
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	q.items = old[:n-1]
+	return item
+}
+
+// userMessage returns the message which was sent to the actor, unwrapping any sequenced message, request or Envelope.
+func userMessage(message any) any {
+	if seq, ok := message.(sequenced); ok {
+		message = seq.message
+	}
+	if req, ok := message.(request); ok {
+		message = req.message
+	}
+	return EnvelopeOf(message).Message
+}
+
+// sequenced is a message numbered in the order in which it was sent to an actor, so that messages in the Mailbox &
+// the controlQueue are processed in a single order.
+type sequenced struct {
+	seq     uint64
+	message any
+}
+
+// unsequenced returns the message wrapped by a sequenced message, or the message itself.
+func unsequenced(message any) any {
+	if seq, ok := message.(sequenced); ok {
+		return seq.message
+	}
+	return message
+}
+
+// controlQueue holds the Message closures sent to an actor, such as handler registrations and Stop. It has no limit on
+// the number of messages, so that they are never dropped or rejected by the OverflowPolicy of the actor's Mailbox. It
+// also numbers the messages added to the Mailbox, so that each closure is processed after the messages sent before it.
+type controlQueue struct {
+	mu       sync.Mutex
+	messages list.List
+	// seq is the sequence number of the last message sent to the actor.
+	seq uint64
+	// wake is called when a message is pushed while the actor is waiting on its Mailbox.
+	wake context.CancelFunc
+	// closed is set once the actor has been asked to stop, since no message after that will be processed.
+	closed bool
+}
+
+// sequence numbers the given message, which is about to be added to the Mailbox.
+func (q *controlQueue) sequence(message any) sequenced {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.seq += 1
+	return sequenced{seq: q.seq, message: message}
+}
+
+// push adds a message to the queue. Returns false if the queue has been closed.
+func (q *controlQueue) push(message any) bool {
+	return q.pushAndClose(message, false)
+}
+
+// close adds a final message to the queue, after which no more messages can be added. Returns false if the queue
+// has already been closed.
+func (q *controlQueue) close(message any) bool {
+	return q.pushAndClose(message, true)
+}
+
+func (q *controlQueue) pushAndClose(message any, close bool) bool {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return false
+	}
+	q.seq += 1
+	q.messages.PushBack(sequenced{seq: q.seq, message: message})
+	q.closed = close
+	wake := q.wake
+	q.wake = nil
+	q.mu.Unlock()
+
+	if wake != nil {
+		wake()
+	}
+	return true
+}
+
+// popBefore removes the next message from the queue if it was sent before the given Mailbox message, or if
+// mailboxMessage is nil. The second return value is false otherwise.
+func (q *controlQueue) popBefore(mailboxMessage *sequenced) (any, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	front := q.messages.Front()
+	if front == nil {
+		return nil, false
+	}
+	if mailboxMessage != nil && mailboxMessage.seq < front.Value.(sequenced).seq {
+		return nil, false
+	}
+	return q.messages.Remove(front).(sequenced).message, true
+}
+
+func (q *controlQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.messages.Len()
+}
+
+// waitContext returns a context derived from parent which is cancelled once a message is pushed to the queue, or
+// immediately if the queue is not empty.
+func (q *controlQueue) waitContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.messages.Len() > 0 {
+		cancel()
+	} else {
+		q.wake = cancel
+	}
+	return ctx, cancel
+}
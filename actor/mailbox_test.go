@@ -0,0 +1,117 @@
+package actor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// drain removes all messages from the given mailbox.
+func drain(t *testing.T, m Mailbox) []any {
+	var messages []any
+	for m.Len() > 0 {
+		message, err := m.Dequeue(context.Background())
+		assert.NoError(t, err)
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// doneContext returns a context which is already done.
+func doneContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestBoundedMailbox_Block(t *testing.T) {
+	m := NewBoundedMailbox(2, OverflowBlock)
+
+	// A done context only matters once the mailbox is full.
+	assert.NoError(t, m.Enqueue(doneContext(), 1))
+	assert.NoError(t, m.Enqueue(context.Background(), 2))
+	assert.ErrorIs(t, m.Enqueue(doneContext(), 3), context.Canceled)
+
+	assert.Equal(t, []any{1, 2}, drain(t, m))
+}
+
+func TestBoundedMailbox_DropOldest(t *testing.T) {
+	m := NewBoundedMailbox(2, OverflowDropOldest)
+
+	for i := 1; i <= 4; i++ {
+		assert.NoError(t, m.Enqueue(context.Background(), i))
+	}
+	assert.Equal(t, []any{3, 4}, drain(t, m))
+}
+
+func TestBoundedMailbox_DropNewest(t *testing.T) {
+	m := NewBoundedMailbox(2, OverflowDropNewest)
+
+	for i := 1; i <= 4; i++ {
+		assert.NoError(t, m.Enqueue(context.Background(), i))
+	}
+	assert.Equal(t, []any{1, 2}, drain(t, m))
+}
+
+func TestBoundedMailbox_Reject(t *testing.T) {
+	m := NewBoundedMailbox(2, OverflowReject)
+
+	assert.NoError(t, m.Enqueue(context.Background(), 1))
+	assert.NoError(t, m.Enqueue(context.Background(), 2))
+	assert.ErrorIs(t, m.Enqueue(context.Background(), 3), ErrMailboxFull)
+
+	assert.Equal(t, []any{1, 2}, drain(t, m))
+}
+
+func TestUnboundedMailbox(t *testing.T) {
+	m := NewUnboundedMailbox()
+
+	for i := 1; i <= 1000; i++ {
+		assert.NoError(t, m.Enqueue(doneContext(), i))
+	}
+	messages := drain(t, m)
+	assert.Len(t, messages, 1000)
+	assert.Equal(t, 1, messages[0])
+	assert.Equal(t, 1000, messages[999])
+
+	_, err := m.Dequeue(doneContext())
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestUnboundedMailbox_Dequeue(t *testing.T) {
+	m := NewUnboundedMailbox()
+
+	// Dequeue blocks until a message is available.
+	messageChan := make(chan any)
+	go func() {
+		message, _ := m.Dequeue(context.Background())
+		messageChan <- message
+	}()
+	assert.NoError(t, m.Enqueue(context.Background(), 1))
+	assert.Equal(t, 1, <-messageChan)
+}
+
+type priorityMessage struct {
+	priority int
+	message  string
+}
+
+func TestPriorityMailbox(t *testing.T) {
+	m := NewPriorityMailbox(func(a any, b any) bool {
+		return a.(priorityMessage).priority > b.(priorityMessage).priority
+	})
+
+	for _, message := range []priorityMessage{{1, "a"}, {2, "b"}, {1, "c"}, {3, "d"}, {2, "e"}} {
+		assert.NoError(t, m.Enqueue(context.Background(), message))
+	}
+
+	// Messages of equal priority are processed in the order in which they were received.
+	assert.Equal(t, []any{
+		priorityMessage{3, "d"},
+		priorityMessage{2, "b"},
+		priorityMessage{2, "e"},
+		priorityMessage{1, "a"},
+		priorityMessage{1, "c"},
+	}, drain(t, m))
+}
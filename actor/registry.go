@@ -1,8 +1,12 @@
 package actor
 
+import "path"
+
 type registry struct {
 	// actors are used to resolve an ActorKey to an Actor
 	actors map[Key]Actor
+	// keys are ordered by the time at which each actor was first registered.
+	keys []Key
 }
 
 func NewRegistry() Registry {
@@ -13,6 +17,9 @@ func NewRegistry() Registry {
 
 func (a *registry) RegisterActor(actor Actor) Key {
 	actorKey := actor.GetKey()
+	if _, ok := a.actors[actorKey]; !ok {
+		a.keys = append(a.keys, actorKey)
+	}
 	a.actors[actorKey] = actor
 
 	return actorKey
@@ -29,3 +36,36 @@ func (r *registry) IsRegistered(actor Actor) bool {
 
 	return ok
 }
+
+func (r *registry) ListActors() []Actor {
+	actors := make([]Actor, 0, len(r.keys))
+	for _, actorKey := range r.keys {
+		actors = append(actors, r.actors[actorKey])
+	}
+	return actors
+}
+
+func (r *registry) ListByType(actorType Type) []Actor {
+	var actors []Actor
+	for _, actorKey := range r.keys {
+		if a := r.actors[actorKey]; a.GetType() == actorType {
+			actors = append(actors, a)
+		}
+	}
+	return actors
+}
+
+func (r *registry) ListByKeyPattern(pattern string) ([]Actor, error) {
+	// The pattern is checked up front, since path.Match only reports a malformed pattern once it reaches it.
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, &InvalidKeyPatternError{Pattern: pattern}
+	}
+
+	var actors []Actor
+	for _, actorKey := range r.keys {
+		if ok, _ := path.Match(pattern, string(actorKey)); ok {
+			actors = append(actors, r.actors[actorKey])
+		}
+	}
+	return actors, nil
+}
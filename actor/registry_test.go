@@ -55,3 +55,60 @@ func TestIsRegistered_NotRegistered(t *testing.T) {
 
 	assert.False(t, ok)
 }
+
+func TestListByType(t *testing.T) {
+	registry := NewRegistry()
+	actorA := NewBasicActor(ActorType, "ActorA")
+	other := NewBasicActor("OtherActor", "ActorB")
+	actorC := NewBasicActor(ActorType, "ActorC")
+	registry.RegisterActor(actorA)
+	registry.RegisterActor(other)
+	registry.RegisterActor(actorC)
+	// Registering an actor again does not change its order.
+	registry.RegisterActor(actorA)
+
+	assert.Equal(t, []Actor{actorA, actorC}, registry.ListByType(ActorType))
+	assert.Empty(t, registry.ListByType("MissingActor"))
+}
+
+func TestListActors(t *testing.T) {
+	registry := NewRegistry()
+	assert.Empty(t, registry.ListActors())
+
+	actorA := NewBasicActor(ActorType, "ActorA")
+	other := NewBasicActor("OtherActor", "ActorB")
+	registry.RegisterActor(actorA)
+	registry.RegisterActor(other)
+	// Registering an actor again does not change its order.
+	registry.RegisterActor(actorA)
+
+	assert.Equal(t, []Actor{actorA, other}, registry.ListActors())
+}
+
+func TestListByKeyPattern(t *testing.T) {
+	registry := NewRegistry()
+	actorA := NewBasicActor(ActorType, "ActorA")
+	other := NewBasicActor("OtherActor", "ActorB")
+	actorC := NewBasicActor(ActorType, "ActorC")
+	registry.RegisterActor(actorA)
+	registry.RegisterActor(other)
+	registry.RegisterActor(actorC)
+
+	actors, err := registry.ListByKeyPattern("Actor:*")
+	assert.NoError(t, err)
+	assert.Equal(t, []Actor{actorA, actorC}, actors)
+
+	actors, err = registry.ListByKeyPattern("*:Actor[AB]")
+	assert.NoError(t, err)
+	assert.Equal(t, []Actor{actorA, other}, actors)
+
+	actors, err = registry.ListByKeyPattern("Missing:*")
+	assert.NoError(t, err)
+	assert.Empty(t, actors)
+
+	_, err = registry.ListByKeyPattern("Actor:[")
+	var patternErr *InvalidKeyPatternError
+	assert.ErrorAs(t, err, &patternErr)
+	assert.ErrorIs(t, err, ErrInvalidKeyPattern)
+	assert.Equal(t, "Actor:[", patternErr.Pattern)
+}
@@ -0,0 +1,61 @@
+package actor
+
+import "context"
+
+// StatefulActor is a BasicActor which owns a value of type S. The state is only accessed from the actor's event
+// loop, so handlers registered through HandleState can modify it without any additional synchronization.
+type StatefulActor[S any] struct {
+	*BasicActor
+	// newState returns the initial state for the actor.
+	newState func() S
+	state    S
+}
+
+// NewStatefulActor creates a StatefulActor whose state is initialized by newState. newState is called again each
+// time the state is reset. The options are applied to the underlying BasicActor.
+func NewStatefulActor[S any](actorType Type, actorId Id, newState func() S, opts ...BasicActorOpt) *StatefulActor[S] {
+	return &StatefulActor[S]{
+		BasicActor: NewBasicActor(actorType, actorId, opts...),
+		newState:   newState,
+		state:      newState(),
+	}
+}
+
+// HandleState registers a handler for messages of type T with the given actor. The handler receives a pointer to
+// the actor's state, which must not be retained once the handler has returned.
+func HandleState[S any, T any](a *StatefulActor[S], handler func(state *S, message T)) {
+	Handle(a, func(message T) {
+		handler(&a.state, message)
+	})
+}
+
+// HandleStateReply registers a handler for messages of type T with the given actor, which replies with a value of
+// type R to callers of Ask.
+func HandleStateReply[S any, T any, R any](a *StatefulActor[S], handler func(state *S, message T) (R, error)) {
+	HandleReply(a, func(message T) (R, error) {
+		return handler(&a.state, message)
+	})
+}
+
+// Snapshot returns a copy of the actor's state, taken after all previously sent messages have been handled. The copy
+// is shallow, so any maps, slices or pointers in the state are shared with the actor and must not be modified. An
+// ActorStoppedError is returned if the actor has stopped.
+func (a *StatefulActor[S]) Snapshot() (S, error) {
+	// The snapshot is taken through the mailbox, so that it waits on the messages which are already in the mailbox.
+	var state S
+	if _, err := a.Ask(context.Background(), Message(func() {
+		state = a.state
+	})); err != nil {
+		var zero S
+		return zero, err
+	}
+	return state, nil
+}
+
+// Reset replaces the actor's state with a new initial state, once all previously sent messages have been handled.
+func (a *StatefulActor[S]) Reset() {
+	// Messages sent to a stopped actor are dropped.
+	_ = a.enqueue(a.stopCtx, Seal(Message(func() {
+		a.state = a.newState()
+	})))
+}
@@ -0,0 +1,120 @@
+package actor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type counterState struct {
+	count    int
+	messages []string
+}
+
+func newCounterActor() *StatefulActor[counterState] {
+	a := NewStatefulActor(ActorType, ActorId, func() counterState {
+		return counterState{}
+	})
+	HandleState(a, func(state *counterState, message testType) {
+		state.count += 1
+		state.messages = append(state.messages, message.message)
+	})
+	HandleStateReply(a, func(state *counterState, message string) (int, error) {
+		return state.count, nil
+	})
+	return a
+}
+
+func TestStatefulActor_HandleState(t *testing.T) {
+	a := newCounterActor()
+
+	for _, message := range []string{"a", "b", "c"} {
+		err := a.SendMessage(testType{message: message})
+		assert.NoError(t, err)
+	}
+
+	count, err := Ask[string, int](context.Background(), a, "count")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	state, err := a.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, counterState{count: 3, messages: []string{"a", "b", "c"}}, state)
+}
+
+// blockActor blocks the actor's event loop until the returned channel is closed.
+func blockActor(a *StatefulActor[counterState]) chan bool {
+	started := make(chan bool)
+	unblock := make(chan bool)
+	a.Notify(func() {
+		started <- true
+		<-unblock
+	})
+	<-started
+	return unblock
+}
+
+func TestStatefulActor_Snapshot(t *testing.T) {
+	a := newCounterActor()
+	unblock := blockActor(a)
+
+	// The snapshot is taken once the messages already in the mailbox have been handled.
+	for _, message := range []string{"a", "b", "c"} {
+		assert.NoError(t, a.TrySendMessage(testType{message: message}))
+	}
+	snapshots := make(chan counterState, 1)
+	go func() {
+		state, err := a.Snapshot()
+		assert.NoError(t, err)
+		snapshots <- state
+	}()
+	assert.Eventually(t, func() bool {
+		return a.MailboxLen() == 4
+	}, time.Second, time.Millisecond)
+	close(unblock)
+
+	assert.Equal(t, counterState{count: 3, messages: []string{"a", "b", "c"}}, <-snapshots)
+}
+
+func TestStatefulActor_Reset(t *testing.T) {
+	a := newCounterActor()
+	unblock := blockActor(a)
+
+	// The state is reset once the messages already in the mailbox have been handled.
+	assert.NoError(t, a.TrySendMessage(testType{message: "a"}))
+	a.Reset()
+	assert.NoError(t, a.TrySendMessage(testType{message: "b"}))
+	close(unblock)
+
+	state, err := a.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, counterState{count: 1, messages: []string{"b"}}, state)
+}
+
+func TestStatefulActor_SnapshotAfterStop(t *testing.T) {
+	a := newCounterActor()
+	a.Stop()
+	a.Wait()
+
+	_, err := a.Snapshot()
+	assert.ErrorIs(t, err, ErrActorStopped)
+}
+
+func TestStatefulActor_WithDeferredStart(t *testing.T) {
+	a := NewStatefulActor(ActorType, ActorId, func() counterState {
+		return counterState{}
+	}, WithDeferredStart())
+	HandleState(a, func(state *counterState, message testType) {
+		state.count += 1
+	})
+
+	// The actor is created with the given options, so messages are only handled once it has been started.
+	assert.NoError(t, a.TrySendMessage(testType{message: "a"}))
+	a.Start()
+
+	state, err := a.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, state.count)
+}
@@ -12,18 +12,50 @@ type statusManager struct {
 	// knownStatusByActor determines the current status of an Actor.
 	knownStatusByActor    map[Key]Status
 	previousStatusByActor map[Key]map[Status]interface{}
+	// cancelledStatusByActor determines what status an Actor moves to when a transition is cancelled.
+	cancelledStatusByActor map[Key]Status
+	// pendingStatusesByActor are the statuses an Actor will transition to once its current transition has completed.
+	pendingStatusesByActor map[Key][]Status
+	// pendingStatusPolicy determines how pendingStatusesByActor is populated.
+	pendingStatusPolicy PendingStatusPolicy
+	// routeByActor are the intermediate statuses an Actor transitions through to reach a status which is not
+	// directly reachable from its known status.
+	routeByActor map[Key][]Status
+	// parentStatusByActor determines which status a given status is nested under for an Actor.
+	parentStatusByActor map[Key]map[Status]Status
 }
 
-func NewStatusManager() StatusManager {
-	return &statusManager{
-		initialStatusByActor:  map[Key]Status{},
-		terminalStatusByActor: map[Key]Status{},
-		desiredStatusByActor:  map[Key]Status{},
-		knownStatusByActor:    map[Key]Status{},
-		previousStatusByActor: map[Key]map[Status]interface{}{},
+type StatusManagerOpt func(a *statusManager)
+
+// WithPendingStatusPolicy sets the policy for handling status updates while an actor is transitioning. By default,
+// PendingStatusQueue is used.
+func WithPendingStatusPolicy(policy PendingStatusPolicy) StatusManagerOpt {
+	return func(a *statusManager) {
+		a.pendingStatusPolicy = policy
 	}
 }
 
+func NewStatusManager(opts ...StatusManagerOpt) StatusManager {
+	a := &statusManager{
+		initialStatusByActor:   map[Key]Status{},
+		terminalStatusByActor:  map[Key]Status{},
+		desiredStatusByActor:   map[Key]Status{},
+		knownStatusByActor:     map[Key]Status{},
+		previousStatusByActor:  map[Key]map[Status]interface{}{},
+		cancelledStatusByActor: map[Key]Status{},
+		pendingStatusesByActor: map[Key][]Status{},
+		pendingStatusPolicy:    PendingStatusQueue,
+		routeByActor:           map[Key][]Status{},
+		parentStatusByActor:    map[Key]map[Status]Status{},
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
 func (a *statusManager) InitializeActor(actorKey Key, initStatus Status, terminalStatus Status) {
 	a.initialStatusByActor[actorKey] = initStatus
 	a.terminalStatusByActor[actorKey] = terminalStatus
@@ -36,7 +68,7 @@ func (a *statusManager) InitializeActor(actorKey Key, initStatus Status, termina
 
 func (a *statusManager) IsValidSubscriptionStatus(actorKey Key, status Status) bool {
 	currentKnownStatus := a.knownStatusByActor[actorKey]
-	if currentKnownStatus == status {
+	if a.IsInLineage(actorKey, currentKnownStatus, status) {
 		return false
 	}
 	_, ok := a.previousStatusByActor[actorKey][status]
@@ -65,6 +97,13 @@ func (a *statusManager) GetKnownStatus(actorKey Key) Status {
 func (a *statusManager) SetKnownStatus(actorKey Key, status Status) {
 	previousKnownStatus := a.knownStatusByActor[actorKey]
 	a.previousStatusByActor[actorKey][previousKnownStatus] = struct{}{}
+	// A parent status has only past once the actor is no longer in any of its children.
+	lineage := a.GetLineage(actorKey, status)
+	for _, prev := range a.GetLineage(actorKey, previousKnownStatus) {
+		if !containsStatus(lineage, prev) {
+			a.previousStatusByActor[actorKey][prev] = struct{}{}
+		}
+	}
 
 	a.knownStatusByActor[actorKey] = status
 }
@@ -84,3 +123,97 @@ func (a *statusManager) GetInitialStatus(actorKey Key) Status {
 func (a *statusManager) GetTerminalStatus(actorKey Key) Status {
 	return a.terminalStatusByActor[actorKey]
 }
+
+func (a *statusManager) SetCancelledStatus(actorKey Key, status Status) {
+	a.cancelledStatusByActor[actorKey] = status
+}
+
+func (a *statusManager) GetCancelledStatus(actorKey Key) (Status, bool) {
+	status, ok := a.cancelledStatusByActor[actorKey]
+	return status, ok
+}
+
+func (a *statusManager) EnqueuePendingStatus(actorKey Key, status Status) bool {
+	switch a.pendingStatusPolicy {
+	case PendingStatusReject:
+		return false
+	case PendingStatusCoalesce:
+		a.pendingStatusesByActor[actorKey] = []Status{status}
+	default:
+		a.pendingStatusesByActor[actorKey] = append(a.pendingStatusesByActor[actorKey], status)
+	}
+	return true
+}
+
+func (a *statusManager) DequeuePendingStatus(actorKey Key) (Status, bool) {
+	pendingStatuses := a.pendingStatusesByActor[actorKey]
+	if len(pendingStatuses) == 0 {
+		return "", false
+	}
+	status := pendingStatuses[0]
+	if len(pendingStatuses) == 1 {
+		delete(a.pendingStatusesByActor, actorKey)
+	} else {
+		a.pendingStatusesByActor[actorKey] = pendingStatuses[1:]
+	}
+	return status, true
+}
+
+func (a *statusManager) ClearPendingStatuses(actorKey Key) {
+	delete(a.pendingStatusesByActor, actorKey)
+}
+
+func (a *statusManager) SetRoute(actorKey Key, route []Status) {
+	if len(route) == 0 {
+		delete(a.routeByActor, actorKey)
+		return
+	}
+	a.routeByActor[actorKey] = route
+}
+
+func (a *statusManager) NextRouteStatus(actorKey Key) (Status, bool) {
+	route := a.routeByActor[actorKey]
+	if len(route) == 0 {
+		return "", false
+	}
+	a.SetRoute(actorKey, route[1:])
+	return route[0], true
+}
+
+func (a *statusManager) ClearRoute(actorKey Key) {
+	delete(a.routeByActor, actorKey)
+}
+
+func (a *statusManager) AddChildStatus(actorKey Key, parentStatus Status, childStatus Status) {
+	if _, ok := a.parentStatusByActor[actorKey]; !ok {
+		a.parentStatusByActor[actorKey] = map[Status]Status{}
+	}
+	a.parentStatusByActor[actorKey][childStatus] = parentStatus
+}
+
+func (a *statusManager) GetParentStatus(actorKey Key, status Status) (Status, bool) {
+	parent, ok := a.parentStatusByActor[actorKey][status]
+	return parent, ok
+}
+
+func (a *statusManager) GetLineage(actorKey Key, status Status) []Status {
+	lineage := []Status{status}
+	for parent, ok := a.GetParentStatus(actorKey, status); ok; parent, ok = a.GetParentStatus(actorKey, parent) {
+		lineage = append(lineage, parent)
+	}
+	return lineage
+}
+
+func (a *statusManager) IsInLineage(actorKey Key, status Status, ancestor Status) bool {
+	return containsStatus(a.GetLineage(actorKey, status), ancestor)
+}
+
+// containsStatus returns true if the given status is in statuses.
+func containsStatus(statuses []Status, status Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -46,3 +46,124 @@ func TestIsValidTransitionStatus(t *testing.T) {
 	assert.True(t, mgr.IsValidTransitionStatus(ActorKey, MidStatus, TerminalStatus))
 	assert.True(t, mgr.IsValidTransitionStatus(ActorKey, InitStatus, TerminalStatus))
 }
+
+func TestCancelledStatus(t *testing.T) {
+	mgr := NewStatusManager()
+	mgr.InitializeActor(ActorKey, InitStatus, TerminalStatus)
+
+	_, ok := mgr.GetCancelledStatus(ActorKey)
+	assert.False(t, ok)
+
+	mgr.SetCancelledStatus(ActorKey, TerminalStatus)
+	status, ok := mgr.GetCancelledStatus(ActorKey)
+	assert.True(t, ok)
+	assert.Equal(t, TerminalStatus, status)
+}
+
+type PendingStatusPolicyTest struct {
+	policy   PendingStatusPolicy
+	msg      string
+	accepted bool
+	expected []Status
+}
+
+func TestPendingStatuses(t *testing.T) {
+	tests := []*PendingStatusPolicyTest{
+		{policy: PendingStatusQueue, accepted: true, expected: []Status{MidStatus, TerminalStatus},
+			msg: "queue all pending statuses in order"},
+		{policy: PendingStatusCoalesce, accepted: true, expected: []Status{TerminalStatus},
+			msg: "only keep the latest pending status"},
+		{policy: PendingStatusReject, accepted: false, expected: nil,
+			msg: "reject all pending statuses"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			mgr := NewStatusManager(WithPendingStatusPolicy(test.policy))
+			mgr.InitializeActor(ActorKey, InitStatus, TerminalStatus)
+
+			assert.Equal(t, test.accepted, mgr.EnqueuePendingStatus(ActorKey, MidStatus))
+			assert.Equal(t, test.accepted, mgr.EnqueuePendingStatus(ActorKey, TerminalStatus))
+
+			var statuses []Status
+			for status, ok := mgr.DequeuePendingStatus(ActorKey); ok; status, ok = mgr.DequeuePendingStatus(ActorKey) {
+				statuses = append(statuses, status)
+			}
+			assert.Equal(t, test.expected, statuses)
+		})
+	}
+}
+
+func TestClearPendingStatuses(t *testing.T) {
+	mgr := NewStatusManager()
+	mgr.InitializeActor(ActorKey, InitStatus, TerminalStatus)
+	mgr.EnqueuePendingStatus(ActorKey, MidStatus)
+
+	mgr.ClearPendingStatuses(ActorKey)
+
+	_, ok := mgr.DequeuePendingStatus(ActorKey)
+	assert.False(t, ok)
+}
+
+func TestRoute(t *testing.T) {
+	mgr := NewStatusManager()
+	mgr.InitializeActor(ActorKey, InitStatus, TerminalStatus)
+	mgr.SetRoute(ActorKey, []Status{MidStatus, TerminalStatus})
+
+	status, ok := mgr.NextRouteStatus(ActorKey)
+	assert.True(t, ok)
+	assert.Equal(t, MidStatus, status)
+
+	mgr.ClearRoute(ActorKey)
+	_, ok = mgr.NextRouteStatus(ActorKey)
+	assert.False(t, ok)
+}
+
+func TestGetLineage(t *testing.T) {
+	mgr := NewStatusManager()
+	mgr.InitializeActor(ActorKey, InitStatus, TerminalStatus)
+	mgr.AddChildStatus(ActorKey, MidStatus, "Mid.Child")
+	mgr.AddChildStatus(ActorKey, "Mid.Child", "Mid.Child.Grandchild")
+
+	parent, ok := mgr.GetParentStatus(ActorKey, "Mid.Child")
+	assert.True(t, ok)
+	assert.Equal(t, MidStatus, parent)
+
+	_, ok = mgr.GetParentStatus(ActorKey, MidStatus)
+	assert.False(t, ok)
+
+	assert.Equal(t, []Status{"Mid.Child.Grandchild", "Mid.Child", MidStatus}, mgr.GetLineage(ActorKey, "Mid.Child.Grandchild"))
+	assert.Equal(t, []Status{InitStatus}, mgr.GetLineage(ActorKey, InitStatus))
+}
+
+func TestIsInLineage(t *testing.T) {
+	mgr := NewStatusManager()
+	mgr.InitializeActor(ActorKey, InitStatus, TerminalStatus)
+	mgr.AddChildStatus(ActorKey, MidStatus, "Mid.Child")
+	mgr.AddChildStatus(ActorKey, "Mid.Child", "Mid.Child.Grandchild")
+
+	assert.True(t, mgr.IsInLineage(ActorKey, "Mid.Child.Grandchild", "Mid.Child.Grandchild"))
+	assert.True(t, mgr.IsInLineage(ActorKey, "Mid.Child.Grandchild", MidStatus))
+	assert.False(t, mgr.IsInLineage(ActorKey, MidStatus, "Mid.Child"))
+	assert.False(t, mgr.IsInLineage(ActorKey, "Mid.Child", InitStatus))
+}
+
+func TestIsValidSubscriptionStatus_ChildStatus(t *testing.T) {
+	mgr := NewStatusManager()
+	mgr.InitializeActor(ActorKey, InitStatus, TerminalStatus)
+	mgr.AddChildStatus(ActorKey, MidStatus, "Mid.A")
+	mgr.AddChildStatus(ActorKey, MidStatus, "Mid.B")
+	mgr.SetKnownStatus(ActorKey, "Mid.A")
+
+	// The actor is already in the parent status.
+	assert.False(t, mgr.IsValidSubscriptionStatus(ActorKey, MidStatus))
+	assert.True(t, mgr.IsValidSubscriptionStatus(ActorKey, "Mid.B"))
+
+	// Moving between children does not leave the parent status.
+	mgr.SetKnownStatus(ActorKey, "Mid.B")
+	assert.False(t, mgr.IsValidSubscriptionStatus(ActorKey, "Mid.A"))
+	assert.False(t, mgr.IsValidSubscriptionStatus(ActorKey, MidStatus))
+
+	mgr.SetKnownStatus(ActorKey, TerminalStatus)
+	assert.False(t, mgr.IsValidSubscriptionStatus(ActorKey, MidStatus))
+}
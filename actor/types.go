@@ -1,5 +1,10 @@
 package actor
 
+import (
+	"reflect"
+	"time"
+)
+
 // Key is a composite of the Type and Id which will uniquely identify an actor.
 type Key string
 
@@ -15,11 +20,98 @@ type Status string
 // Message represents some unit of computation that the actor processes.
 type Message func()
 
-// messageType is used to register the Handler for the Message type.
-var messageType = Message(func() {})
+// MessageType identifies the messages which a Handler is registered for. A MessageType is created once, when the
+// Handler is registered, so that each message is matched to its Handler without reflection.
+type MessageType struct {
+	// key identifies the type, so that registering a Handler for a type replaces any previous Handler for it.
+	key reflect.Type
+	// accepts returns true if the message is of this type.
+	accepts func(message any) bool
+}
+
+// TypeOf returns the MessageType for messages of type T. If T is an interface, then the MessageType accepts any
+// message which implements T.
+func TypeOf[T any]() MessageType {
+	return MessageType{
+		key: reflect.TypeOf((*T)(nil)).Elem(),
+		accepts: func(message any) bool {
+			_, ok := message.(T)
+			return ok
+		},
+	}
+}
 
-// mailbox is the internal mailbox type used to store incoming messages which are waiting to be processed.
-type mailbox chan any
+func (t MessageType) isInterface() bool {
+	return t.key != nil && t.key.Kind() == reflect.Interface
+}
+
+// typeOfSample returns the MessageType for messages with the same dynamic type as sample, unless sample is already a
+// MessageType.
+func typeOfSample(sample any) MessageType {
+	if messageType, ok := sample.(MessageType); ok {
+		return messageType
+	}
+	key := reflect.TypeOf(sample)
+	return MessageType{
+		key: key,
+		accepts: func(message any) bool {
+			return reflect.TypeOf(message) == key
+		},
+	}
+}
 
 // Handler is a function which can process an incoming message to an actor.
 type Handler func(message any)
+
+// registeredHandler is a ReplyHandler along with the MessageType which it was registered for.
+type registeredHandler struct {
+	messageType MessageType
+	handler     ReplyHandler
+}
+
+// ReplyHandler is a function which can process an incoming message to an actor, and return a reply to the sender.
+type ReplyHandler func(message any) (any, error)
+
+// Middleware wraps a ReplyHandler, for example to add logging or metrics to each message an actor handles.
+type Middleware func(next ReplyHandler) ReplyHandler
+
+// PanicHandler is a function which receives the value recovered from a panic, along with the message that was being
+// handled.
+type PanicHandler func(message any, recovered any)
+
+// request wraps a message sent through Ask, along with the channel on which to send the reply.
+type request struct {
+	message any
+	reply   chan<- reply
+}
+
+// reply is the result of handling a request.
+type reply struct {
+	value any
+	err   error
+}
+
+// DeadLetter is a message which could not be delivered to an actor.
+type DeadLetter struct {
+	// ActorKey identifies the actor which the message was sent to.
+	ActorKey Key
+	Message  any
+	// Reason is the error which prevented the message from being delivered.
+	Reason    error
+	Timestamp time.Time
+}
+
+// DeadLetterHandler is a function which receives each message that could not be delivered.
+type DeadLetterHandler func(deadLetter DeadLetter)
+
+// PendingStatusPolicy determines how status updates are handled when an actor is already transitioning.
+type PendingStatusPolicy int
+
+const (
+	// PendingStatusQueue queues each status update, which are then processed in the order they were received.
+	PendingStatusQueue PendingStatusPolicy = iota
+	// PendingStatusCoalesce keeps only the most recent status update.
+	PendingStatusCoalesce
+	// PendingStatusReject rejects status updates while the actor is transitioning.
+	PendingStatusReject
+)
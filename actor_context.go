@@ -0,0 +1,142 @@
+package slashie
+
+import (
+	"context"
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/logger"
+)
+
+// ActorContext gives handlers and transition actions access to the actor they are running on, and to slashie.
+type ActorContext interface {
+	// Context returns the context.Context for the handler or action. For transition actions, it is cancelled when
+	// the transition is cancelled.
+	Context() context.Context
+	// Self returns the actor which is handling the message or running the action.
+	Self() actor.Actor
+	// Sender returns the Key of the actor which sent the message being handled, or an empty Key if the message was
+	// not sent by an actor.
+	Sender() actor.Key
+	// Envelope returns the Envelope for the message being handled. Transition actions receive an empty Envelope.
+	Envelope() actor.Envelope
+	// Send sends a follow-up message to the given actor, propagating the correlation Id and headers of the message
+	// being handled. Like SendMessage, it waits for the actor to check the message type, so an actor must not Send to
+	// itself.
+	Send(actorKey actor.Key, message any) error
+	// Publish publishes a follow-up message to the given topic in the same way as Send. Unlike Send, it does not wait
+	// on any subscriber, so an actor may publish to a topic which it subscribes to.
+	Publish(topic string, message any) error
+	// Ask sends a follow-up message to the given actor in the same way as Send, and waits for the reply. Since the
+	// actor's goroutine is blocked until the reply is received, an actor must not Ask itself.
+	Ask(actorKey actor.Key, message any) (any, error)
+	// Coordinator returns the Slashie which the actor was added to.
+	Coordinator() Slashie
+	// UpdateOwnStatus requests a status update for the actor. Since a handler runs on the actor's goroutine, it cannot
+	// wait for any guards, which are also evaluated on the actor's goroutine. Instead, the update is applied
+	// asynchronously, in the order in which updates were requested, and any error is logged.
+	UpdateOwnStatus(status actor.Status)
+	// Spawn adds a child actor to slashie, and returns its Key.
+	Spawn(child actor.Actor, initStatus actor.Status, terminalStatus actor.Status) actor.Key
+	// Logger returns the Logger for the actor, which prefixes each message with the actor's Key.
+	Logger() logger.Logger
+}
+
+// ActorAction is a transition action which receives an ActorContext.
+type ActorAction func(ctx ActorContext) error
+
+// asyncStatusUpdater is implemented by slashie, which can request a status update without waiting for the result.
+type asyncStatusUpdater interface {
+	updateStatusAsync(a actor.Actor, status actor.Status)
+}
+
+type actorContext struct {
+	ctx      context.Context
+	self     actor.Actor
+	envelope actor.Envelope
+	s        Slashie
+}
+
+func (c *actorContext) Context() context.Context {
+	return c.ctx
+}
+
+func (c *actorContext) Self() actor.Actor {
+	return c.self
+}
+
+func (c *actorContext) Sender() actor.Key {
+	return c.envelope.Sender
+}
+
+func (c *actorContext) Envelope() actor.Envelope {
+	return c.envelope
+}
+
+func (c *actorContext) Send(actorKey actor.Key, message any) error {
+	return c.s.SendMessage(actorKey, c.followUp(message))
+}
+
+func (c *actorContext) Publish(topic string, message any) error {
+	return c.s.Publish(topic, c.followUp(message))
+}
+
+func (c *actorContext) Ask(actorKey actor.Key, message any) (any, error) {
+	return c.s.Ask(c.ctx, actorKey, c.followUp(message))
+}
+
+// followUp wraps a message sent while handling the current message in an Envelope.
+func (c *actorContext) followUp(message any) actor.Envelope {
+	return c.envelope.FollowUp(c.self.GetKey(), message)
+}
+
+func (c *actorContext) Coordinator() Slashie {
+	return c.s
+}
+
+func (c *actorContext) UpdateOwnStatus(status actor.Status) {
+	if updater, ok := c.s.(asyncStatusUpdater); ok {
+		updater.updateStatusAsync(c.self, status)
+		return
+	}
+	// Other implementations of Slashie can only be called from another goroutine, so the order of updates is lost.
+	go func() {
+		if err := c.s.UpdateStatus(c.self, status); err != nil {
+			c.self.GetLogger().Errorf("Could not update status to %s: %s", status, err)
+		}
+	}()
+}
+
+func (c *actorContext) Spawn(child actor.Actor, initStatus actor.Status, terminalStatus actor.Status) actor.Key {
+	c.s.AddActor(child, initStatus, terminalStatus)
+	return child.GetKey()
+}
+
+func (c *actorContext) Logger() logger.Logger {
+	return c.self.GetLogger()
+}
+
+// newActorContext returns an ActorContext for the message currently being handled by the given actor. It must only
+// be called from a handler.
+func newActorContext(s Slashie, a actor.Actor) ActorContext {
+	return &actorContext{
+		ctx:      context.Background(),
+		self:     a,
+		envelope: a.CurrentEnvelope(),
+		s:        s,
+	}
+}
+
+// Handle registers a handler for messages of type T with the given actor, which receives an ActorContext along with
+// the message. As with actor.Handle, T may be an interface.
+func Handle[T any](s Slashie, a actor.Actor, handler func(ctx ActorContext, message T)) {
+	actor.Handle(a, func(message T) {
+		handler(newActorContext(s, a), message)
+	})
+}
+
+// HandleReply registers a handler for messages of type T with the given actor, which receives an ActorContext along
+// with the message, and replies with a value of type R to callers of Ask.
+func HandleReply[T any, R any](s Slashie, a actor.Actor, handler func(ctx ActorContext, message T) (R, error)) {
+	actor.HandleReply(a, func(message T) (R, error) {
+		return handler(newActorContext(s, a), message)
+	})
+}
@@ -1,35 +1,144 @@
 package slashie
 
 import (
+	"context"
 	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/hook"
+	"github.com/strategicpause/slashie/scheduler"
 	"github.com/strategicpause/slashie/subscription"
 	"github.com/strategicpause/slashie/transition"
+	"time"
 )
 
 // Slashie manages all callbacks and dependencies which establish relationships between the different actors.
 type Slashie interface {
 	// AddActor will register an Actor with the Slashie. This will also specify both the initial status
-	// and terminal status for the given actor.
+	// and terminal status for the given actor. If the actor is a router.Pool, such as a router.Router, then each actor
+	// of its PoolType is added to its pool, including actors which are added later, until they reach their terminal
+	// status.
 	AddActor(actor actor.Actor, initStatus actor.Status, terminalStatus actor.Status)
 	// AddTransitionDependency will add a dependency on the srcActor transitioning to srcStatus until destActor
 	// transitions to destStatus.
 	AddTransitionDependency(srcActor actor.Actor, srcStatus actor.Status, depActor actor.Actor, depStatus actor.Status) error
+	// RemoveTransitionDependency will remove the dependency on the srcActor transitioning to srcStatus until depActor
+	// transitions to depStatus. If this was the last dependency blocking srcActor from transitioning to srcStatus, then
+	// srcActor will immediately begin transitioning if srcStatus is its desired status.
+	RemoveTransitionDependency(srcActor actor.Actor, srcStatus actor.Status, depActor actor.Actor, depStatus actor.Status) error
+	// GetTransitionDependencies returns the actors, and their respective statuses, which the given actor must wait on
+	// before transitioning to the given status.
+	GetTransitionDependencies(actor actor.Actor, status actor.Status) map[actor.Key]actor.Status
+	// GetDependents returns the actors, and the statuses they want to transition to, which are waiting on the given
+	// actor to transition to the given status.
+	GetDependents(actor actor.Actor, status actor.Status) map[actor.Key]actor.Status
 	// AddTransitionAction will register a callback function which will be called before the given actor
 	// transitions from srcStatus to destStatus.
 	AddTransitionAction(actor actor.Actor, srcStatus actor.Status, destStatus actor.Status, callback transition.Action) error
+	// AddTransitionContextAction is the same as AddTransitionAction, except that the callback receives a context which
+	// is cancelled if the transition is cancelled through CancelTransition.
+	AddTransitionContextAction(actor actor.Actor, srcStatus actor.Status, destStatus actor.Status, callback transition.ContextAction) error
+	// AddTransitionActorAction is the same as AddTransitionContextAction, except that the callback receives an
+	// ActorContext for the given actor.
+	AddTransitionActorAction(actor actor.Actor, srcStatus actor.Status, destStatus actor.Status, callback ActorAction) error
+	// AddWildcardTransitionAction will register a callback function which will be called before the given actor
+	// transitions from any status, other than the excludedStatuses, to destStatus. An action registered through
+	// AddTransitionAction for a specific source status takes precedence over wildcard actions. Passing
+	// transition.AnyStatus as the srcStatus to AddTransitionAction is equivalent to calling this with no exclusions.
+	AddWildcardTransitionAction(actor actor.Actor, destStatus actor.Status, callback transition.Action, excludedStatuses ...actor.Status) error
 	// AddTransitionActions registers multiple transition callbacks for a given Actor.
 	AddTransitionActions(actor actor.Actor, transitionCallbacks []*transition.TransitionAction) error
+	// AddTransitionGuard will register a guard which must return true before the given actor can begin transitioning
+	// from srcStatus to destStatus. Guards are evaluated by the actor when UpdateStatus is called, and if any guard
+	// returns false, then UpdateStatus will return a TransitionRejectedError without updating the actor's status.
+	// Since UpdateStatus waits on the actor to evaluate guards, an actor must not request a guarded transition for
+	// itself from its own goroutine.
+	AddTransitionGuard(actor actor.Actor, srcStatus actor.Status, destStatus actor.Status, guard transition.Guard) error
 	// UpdateStatus indicates that the given actor wants to transition to the desiredStatus. Once all of an actor's
 	// dependencies have reached their desired state, then transition callbacks will be called for that actor. Upon
 	// successful completion of transition callbacks, then the actor will successfully move to the desired status.
-	// Once transitioning has completed, then all subscription callbacks will be called.
+	// Once transitioning has completed, then all subscription callbacks will be called. If the actor is already
+	// transitioning, then the update is handled according to the PendingStatusPolicy given through
+	// WithPendingStatusPolicy.
 	UpdateStatus(actor actor.Actor, desiredStatus actor.Status) error
+	// AddChildStatus nests the childStatus under the parentStatus for the given actor. While the actor is in the
+	// childStatus, it is also in the parentStatus: dependencies and subscriptions on the parentStatus are satisfied
+	// when the actor enters any of its children, transitions from the parentStatus apply to each child, and enter &
+	// exit hooks for the parentStatus only run when the actor crosses its boundary.
+	AddChildStatus(actor actor.Actor, parentStatus actor.Status, childStatus actor.Status) error
+	// CancelTransition will cancel the in-flight transition for the given actor. The context given to any running
+	// actions is cancelled, and their results are discarded. The actor will then move to its cancelled status if one
+	// has been set, otherwise its desired status is restored to its known status.
+	CancelTransition(actor actor.Actor, reason string) error
+	// SetCancelledStatus sets the status which the given actor will move to when a transition is cancelled.
+	SetCancelledStatus(actor actor.Actor, status actor.Status) error
 	// GetStatus returns the current known status for an Actor.
 	GetStatus(actor actor.Actor) actor.Status
+	// FindActors returns each actor selected by the given query, in the order in which they were added. The actors
+	// are selected at a single point in time, so the result is consistent with concurrent status updates. An
+	// actor.InvalidKeyPatternError is returned if the query's KeyPattern is malformed.
+	FindActors(query ActorQuery) ([]actor.Actor, error)
+	// Snapshot returns a point-in-time view of slashie and each of its actors, in the order in which they were added.
+	// The snapshot is collected in between the messages processed by slashie, so it is consistent across actors.
+	Snapshot() Snapshot
 	// Subscribe allows anyone to register a callback function to execute once the given actor has transitioned
 	// to the given status.
 	Subscribe(actor actor.Actor, status actor.Status, callback subscription.Subscription) error
+	// SubscribeTopic subscribes the given actor to each topic which matches the given pattern, so that it receives the
+	// messages published to those topics. Topics are made up of segments separated by ".", and patterns may use "*" to
+	// match a single segment, or end with "#" to match any number of trailing segments. Subscriptions are removed
+	// once the actor reaches its terminal status or stops.
+	SubscribeTopic(actor actor.Actor, pattern string) error
+	// UnsubscribeTopic removes the subscription of the given actor to the given pattern. Returns false if the actor
+	// was not subscribed to the pattern.
+	UnsubscribeTopic(actor actor.Actor, pattern string) bool
+	// Publish sends a message to each actor which is subscribed to the given topic through SendMessage. Messages are
+	// queued for each subscriber and delivered in order without waiting, so that no subscriber, including the
+	// publisher itself, can hold up Publish or delivery to the others. The OverflowPolicy of each subscriber's mailbox
+	// applies as the message is delivered. The topic is available to handlers through the TopicHeader of the message's
+	// Envelope. Messages which cannot be delivered, including those which a subscriber has no handler for, are passed
+	// to the dead letter handlers.
+	Publish(topic string, message any) error
+	// AddTimedTransition moves the given actor to destStatus once it has been in the given status for the given
+	// duration. The timer starts each time the actor enters the status, and is cancelled when the actor leaves it. If
+	// the actor is transitioning when the timer fires, then the timed transition is applied once the transition
+	// completes, as long as the actor is still in the status. Any transition actions, guards & dependencies for the
+	// transition still apply. An IllegalTransitionError is returned if the actor cannot transition from the status to
+	// destStatus.
+	AddTimedTransition(actor actor.Actor, status actor.Status, after time.Duration, destStatus actor.Status) error
+	// OnEnter registers a hook which the given actor will execute each time it transitions to the given status,
+	// regardless of the status it transitioned from. Enter hooks are executed before any subscriptions.
+	OnEnter(actor actor.Actor, status actor.Status, hook hook.Hook) error
+	// OnExit registers a hook which the given actor will execute each time it begins transitioning from the given
+	// status, regardless of the status it is transitioning to. Exit hooks are executed before any transition actions.
+	// If the transition is cancelled, the hooks are not executed again until the actor has left the status.
+	OnExit(actor actor.Actor, status actor.Status, hook hook.Hook) error
 	// SendMessage provides the ability to send an arbitrary message to a given actor. If the actor does not support
-	// the given message type, an error will be returned.
+	// the given message type, an error will be returned. The message may be wrapped in an actor.Envelope to identify
+	// its sender, correlation Id & headers, which are otherwise assigned when the message is sent.
 	SendMessage(actorKey actor.Key, message any) error
+	// TrySendMessage sends a message to the given actor without blocking on either the slashie coordinator or the
+	// actor's mailbox. A MailboxFullError is returned if the actor's mailbox is full, so that callers can shed load.
+	TrySendMessage(actorKey actor.Key, message any) error
+	// TrySendMessageUntil behaves like TrySendMessage, but waits until the deadline for room in the actor's mailbox.
+	// The actor is looked up by the slashie coordinator, and a CoordinatorBusyError is returned if it does not do so
+	// before the deadline.
+	TrySendMessageUntil(actorKey actor.Key, deadline time.Time, message any) error
+	// Ask sends a message to the given actor and waits for the reply from the handler registered through
+	// RegisterReplyHandler. An error is returned if the actor does not exist, does not support the given message
+	// type, stops before replying, or if the context is done before the reply is received.
+	Ask(ctx context.Context, actorKey actor.Key, message any) (any, error)
+	// SubscribeDeadLetters adds a handler which receives each message that could not be delivered, either because
+	// the actor does not exist, has stopped, has no handler for the message type, or its mailbox dropped the message.
+	// Handlers are called on the goroutine which found the message to be undeliverable, so they must not block. The
+	// returned function removes the handler.
+	SubscribeDeadLetters(handler actor.DeadLetterHandler) (unsubscribe func())
+	// DeadLetterCount returns the number of messages which could not be delivered.
+	DeadLetterCount() uint64
+	// SendMessageAfter sends a message to the given actor once the delay has elapsed. The returned Handle can be used
+	// to cancel the message before it is delivered.
+	SendMessageAfter(actorKey actor.Key, delay time.Duration, message any) (scheduler.Handle, error)
+	// SendMessageAt sends a message to the given actor at the given time.
+	SendMessageAt(actorKey actor.Key, at time.Time, message any) (scheduler.Handle, error)
+	// SendMessageEvery sends a message to the given actor every interval, until either the returned Handle is
+	// cancelled or the actor stops. If the actor falls behind, then any missed messages are skipped.
+	SendMessageEvery(actorKey actor.Key, interval time.Duration, message any) (scheduler.Handle, error)
 }
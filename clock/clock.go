@@ -0,0 +1,120 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and the ability to schedule functions, so that time can be controlled in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// AfterFunc waits for the duration to elapse and then calls f in its own goroutine. The returned Timer can be
+	// used to cancel the call.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer represents a single scheduled function call.
+type Timer interface {
+	// Stop prevents the Timer from firing. It returns false if the Timer has already fired or been stopped.
+	Stop() bool
+}
+
+type realClock struct{}
+
+// NewRealClock returns a Clock backed by the time package.
+func NewRealClock() Clock {
+	return &realClock{}
+}
+
+func (c *realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c *realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// FakeClock is a Clock which only moves forward when Advance is called. This allows tests to deterministically
+// control when timers fire.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	f     func()
+}
+
+// NewFakeClock returns a FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc schedules f to be called once the clock has been advanced by at least d.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, when: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+
+	return t
+}
+
+// Advance moves the clock forward by d. Any timers which are due are called synchronously, in the order in which
+// they are due, before Advance returns.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due, pending []*fakeTimer
+	for _, t := range c.timers {
+		if t.when.After(c.now) {
+			pending = append(pending, t)
+		} else {
+			due = append(due, t)
+		}
+	}
+	c.timers = pending
+	c.mu.Unlock()
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].when.Before(due[j].when)
+	})
+	for _, t := range due {
+		t.f()
+	}
+}
+
+// NumTimers returns the number of timers which have not yet fired or been stopped.
+func (c *FakeClock) NumTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, timer := range c.timers {
+		if timer == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock_Advance(t *testing.T) {
+	start := time.Unix(0, 0)
+	c := NewFakeClock(start)
+
+	var fired []int
+	c.AfterFunc(2*time.Second, func() {
+		fired = append(fired, 2)
+	})
+	c.AfterFunc(time.Second, func() {
+		fired = append(fired, 1)
+	})
+	c.AfterFunc(3*time.Second, func() {
+		fired = append(fired, 3)
+	})
+
+	c.Advance(500 * time.Millisecond)
+	assert.Empty(t, fired)
+
+	// Timers fire in the order in which they are due.
+	c.Advance(2 * time.Second)
+	assert.Equal(t, []int{1, 2}, fired)
+	assert.Equal(t, start.Add(2500*time.Millisecond), c.Now())
+	assert.Equal(t, 1, c.NumTimers())
+}
+
+func TestFakeClock_Stop(t *testing.T) {
+	c := NewFakeClock(time.Unix(0, 0))
+
+	fired := false
+	timer := c.AfterFunc(time.Second, func() {
+		fired = true
+	})
+
+	assert.True(t, timer.Stop())
+	assert.False(t, timer.Stop())
+
+	c.Advance(time.Second)
+	assert.False(t, fired)
+}
+
+func TestRealClock_AfterFunc(t *testing.T) {
+	c := NewRealClock()
+
+	ch := make(chan bool)
+	c.AfterFunc(time.Millisecond, func() {
+		ch <- true
+	})
+
+	assert.True(t, <-ch)
+	assert.False(t, c.Now().IsZero())
+}
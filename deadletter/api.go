@@ -0,0 +1,16 @@
+package deadletter
+
+import "github.com/strategicpause/slashie/actor"
+
+// Manager collects messages which could not be delivered to an actor. Unlike the other managers, a Manager may be
+// used concurrently, since dead letters are published from each actor's goroutine.
+type Manager interface {
+	// Publish passes the dead letter to each subscriber, and increments the dead letter count.
+	Publish(deadLetter actor.DeadLetter)
+	// Subscribe adds a handler which receives each dead letter published after it was added. Handlers are called on
+	// the goroutine which published the dead letter, so they must not block. The returned function removes the
+	// handler.
+	Subscribe(handler actor.DeadLetterHandler) (unsubscribe func())
+	// Count returns the number of dead letters which have been published.
+	Count() uint64
+}
@@ -0,0 +1,57 @@
+package deadletter
+
+import (
+	"github.com/strategicpause/slashie/actor"
+	"sync"
+	"sync/atomic"
+)
+
+type manager struct {
+	mu sync.RWMutex
+	// handlersById
+	handlersById map[uint64]actor.DeadLetterHandler
+	// nextId is used to assign each handler a unique id, so that it can be removed.
+	nextId uint64
+	count  uint64
+}
+
+func NewManager() Manager {
+	return &manager{
+		handlersById: map[uint64]actor.DeadLetterHandler{},
+	}
+}
+
+func (m *manager) Publish(deadLetter actor.DeadLetter) {
+	atomic.AddUint64(&m.count, 1)
+
+	m.mu.RLock()
+	handlers := make([]actor.DeadLetterHandler, 0, len(m.handlersById))
+	for _, handler := range m.handlersById {
+		handlers = append(handlers, handler)
+	}
+	m.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(deadLetter)
+	}
+}
+
+func (m *manager) Subscribe(handler actor.DeadLetterHandler) func() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextId += 1
+	id := m.nextId
+	m.handlersById[id] = handler
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		delete(m.handlersById, id)
+	}
+}
+
+func (m *manager) Count() uint64 {
+	return atomic.LoadUint64(&m.count)
+}
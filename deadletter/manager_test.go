@@ -0,0 +1,37 @@
+package deadletter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+const ActorKey = "ActorKey"
+
+func TestPublish(t *testing.T) {
+	m := NewManager()
+	deadLetter := actor.DeadLetter{
+		ActorKey:  ActorKey,
+		Message:   "TestMessage",
+		Reason:    &actor.UnknownActorError{ActorKey: ActorKey},
+		Timestamp: time.Unix(0, 0),
+	}
+
+	// Dead letters are counted even without any subscribers.
+	m.Publish(deadLetter)
+	assert.Equal(t, uint64(1), m.Count())
+
+	var received []actor.DeadLetter
+	unsubscribe := m.Subscribe(func(d actor.DeadLetter) {
+		received = append(received, d)
+	})
+	m.Publish(deadLetter)
+	assert.Equal(t, []actor.DeadLetter{deadLetter}, received)
+
+	unsubscribe()
+	m.Publish(deadLetter)
+	assert.Len(t, received, 1)
+	assert.Equal(t, uint64(3), m.Count())
+}
@@ -0,0 +1,65 @@
+package slashie
+
+import (
+	"container/list"
+	"github.com/strategicpause/slashie/actor"
+	"sync"
+)
+
+// delivery is a message which is waiting to be sent to an actor through SendMessage.
+type delivery struct {
+	actor   actor.Actor
+	message any
+	// done is called with the result of SendMessage once the message has been delivered.
+	done func(err error)
+}
+
+// deliveryQueues send messages to actors outside the slashie coordinator. Each actor has its own queue, so that its
+// messages are delivered in the order in which they were pushed, and an actor which is slow to accept messages does
+// not hold up delivery to the others. A queue has no limit on the number of messages, so pushing a message never
+// blocks, while the OverflowPolicy of the actor's Mailbox still applies when the message is delivered.
+type deliveryQueues struct {
+	mu sync.Mutex
+	// queues holds the messages waiting for each actor. An actor only has a queue while its messages are being
+	// delivered.
+	queues map[actor.Key]*list.List
+}
+
+func newDeliveryQueues() *deliveryQueues {
+	return &deliveryQueues{queues: map[actor.Key]*list.List{}}
+}
+
+// push adds a message to the queue for its actor, and starts delivering the queue if it is not already being
+// delivered.
+func (q *deliveryQueues) push(d delivery) {
+	actorKey := d.actor.GetKey()
+
+	q.mu.Lock()
+	queue, ok := q.queues[actorKey]
+	if !ok {
+		queue = list.New()
+		q.queues[actorKey] = queue
+	}
+	queue.PushBack(d)
+	q.mu.Unlock()
+
+	if !ok {
+		go q.deliver(actorKey, queue)
+	}
+}
+
+// deliver sends each message in the queue to its actor, until the queue is empty.
+func (q *deliveryQueues) deliver(actorKey actor.Key, queue *list.List) {
+	for {
+		q.mu.Lock()
+		if queue.Len() == 0 {
+			delete(q.queues, actorKey)
+			q.mu.Unlock()
+			return
+		}
+		d := queue.Remove(queue.Front()).(delivery)
+		q.mu.Unlock()
+
+		d.done(d.actor.SendMessage(d.message))
+	}
+}
@@ -11,4 +11,17 @@ type Manager interface {
 	HasTransitionDependencies(actorKey actor.Key, status actor.Status) bool
 	// NotifyDependenciesOfStatus will
 	NotifyDependenciesOfStatus(actorKey actor.Key, newStatus actor.Status, callback func(actor.Key))
+	// RemoveTransitionDependency removes the dependency of srcActor transitioning to srcStatus on depActor
+	// transitioning to depStatus. If this was the last dependency blocking srcActor from transitioning to srcStatus,
+	// then the callback will be called with srcActor. An error is returned if the dependency does not exist.
+	RemoveTransitionDependency(srcActor actor.Key, srcStatus actor.Status, depActor actor.Key, depStatus actor.Status, callback func(actor.Key)) error
+	// GetTransitionDependencies returns the actors, and their respective statuses, which the given actor is waiting on
+	// before it can transition to the given status.
+	GetTransitionDependencies(actorKey actor.Key, status actor.Status) map[actor.Key]actor.Status
+	// GetDependents returns the actors, and the statuses they want to transition to, which are waiting on the given
+	// actor to transition to the given status.
+	GetDependents(actorKey actor.Key, status actor.Status) map[actor.Key]actor.Status
+	// GetAllTransitionDependencies returns the dependencies which the given actor is waiting on for each status that
+	// it has unsatisfied dependencies for.
+	GetAllTransitionDependencies(actorKey actor.Key) map[actor.Status]map[actor.Key]actor.Status
 }
@@ -0,0 +1,50 @@
+package dependency
+
+import (
+	"errors"
+	"fmt"
+	"github.com/strategicpause/slashie/actor"
+	"strings"
+)
+
+var (
+	// ErrDependencyCycle is returned when adding a transition dependency would result in a circular dependency.
+	ErrDependencyCycle = errors.New("dependency cycle")
+	// ErrUnknownDependency is returned when a transition dependency does not exist.
+	ErrUnknownDependency = errors.New("unknown dependency")
+)
+
+// CycleError is returned when adding a transition dependency would result in a circular dependency. Path contains
+// each actor & status which forms the cycle, starting and ending with the actor & status of the rejected dependency.
+type CycleError struct {
+	Path []ActorStatusKey
+}
+
+func (e *CycleError) Error() string {
+	path := make([]string, len(e.Path))
+	for i, key := range e.Path {
+		path[i] = key.String()
+	}
+	return fmt.Sprintf("circular dependency detected: %s", strings.Join(path, " -> "))
+}
+
+func (e *CycleError) Is(target error) bool {
+	return target == ErrDependencyCycle
+}
+
+// UnknownDependencyError indicates that SrcActor transitioning to SrcStatus does not depend on DepActor transitioning
+// to DepStatus.
+type UnknownDependencyError struct {
+	SrcActor  actor.Key
+	SrcStatus actor.Status
+	DepActor  actor.Key
+	DepStatus actor.Status
+}
+
+func (e *UnknownDependencyError) Error() string {
+	return fmt.Sprintf("%s does not depend on %s transitioning to %s before transitioning to %s", e.SrcActor, e.DepActor, e.DepStatus, e.SrcStatus)
+}
+
+func (e *UnknownDependencyError) Is(target error) bool {
+	return target == ErrUnknownDependency
+}
@@ -1,7 +1,6 @@
 package dependency
 
 import (
-	"errors"
 	"github.com/strategicpause/slashie/actor"
 )
 
@@ -49,6 +48,10 @@ func (t *manager) NotifyDependenciesOfStatus(actorKey actor.Key, newStatus actor
 }
 
 func (t *manager) AddTransitionDependency(srcActor actor.Key, srcStatus actor.Status, depActor actor.Key, depStatus actor.Status) error {
+	if err := t.validateTransitionDependency(srcActor, srcStatus, depActor, depStatus); err != nil {
+		return err
+	}
+
 	if _, ok := t.transitionDependenciesByActor[srcActor]; !ok {
 		t.transitionDependenciesByActor[srcActor] = map[actor.Status]map[actor.Key]actor.Status{}
 	}
@@ -57,51 +60,104 @@ func (t *manager) AddTransitionDependency(srcActor actor.Key, srcStatus actor.St
 		transitionDependencies[srcStatus] = map[actor.Key]actor.Status{}
 	}
 	dependencies := transitionDependencies[srcStatus]
+	// If srcActor previously depended on a different status for depActor, then that dependency is replaced.
+	if prevStatus, ok := dependencies[depActor]; ok && prevStatus != depStatus {
+		delete(t.reverseDependencies[depActor][prevStatus], srcActor)
+	}
 	dependencies[depActor] = depStatus
-	err := t.validateTransitionDependencies(srcActor, srcStatus)
-	// If this results in an invalid state, then undo the previous action
-	if err != nil {
-		transitionDependencies[srcStatus] = map[actor.Key]actor.Status{}
-	} else {
-		if _, ok := t.reverseDependencies[depActor]; !ok {
-			t.reverseDependencies[depActor] = make(map[actor.Status]map[actor.Key]actor.Status)
-		}
-		if _, ok := t.reverseDependencies[depActor][depStatus]; !ok {
-			t.reverseDependencies[depActor][depStatus] = make(map[actor.Key]actor.Status)
-		}
-		t.reverseDependencies[depActor][depStatus][srcActor] = srcStatus
+
+	if _, ok := t.reverseDependencies[depActor]; !ok {
+		t.reverseDependencies[depActor] = make(map[actor.Status]map[actor.Key]actor.Status)
+	}
+	if _, ok := t.reverseDependencies[depActor][depStatus]; !ok {
+		t.reverseDependencies[depActor][depStatus] = make(map[actor.Key]actor.Status)
+	}
+	t.reverseDependencies[depActor][depStatus][srcActor] = srcStatus
+
+	return nil
+}
+
+func (t *manager) RemoveTransitionDependency(srcActor actor.Key, srcStatus actor.Status, depActor actor.Key, depStatus actor.Status, callback func(actor.Key)) error {
+	dependencies := t.transitionDependenciesByActor[srcActor][srcStatus]
+	if status, ok := dependencies[depActor]; !ok || status != depStatus {
+		return &UnknownDependencyError{SrcActor: srcActor, SrcStatus: srcStatus, DepActor: depActor, DepStatus: depStatus}
+	}
+	delete(dependencies, depActor)
+	if len(dependencies) == 0 {
+		delete(t.transitionDependenciesByActor[srcActor], srcStatus)
+	}
+
+	dependents := t.reverseDependencies[depActor][depStatus]
+	delete(dependents, srcActor)
+	if len(dependents) == 0 {
+		delete(t.reverseDependencies[depActor], depStatus)
+	}
+
+	// This means the given actor no longer has any dependencies and can transition to srcStatus.
+	if !t.HasTransitionDependencies(srcActor, srcStatus) {
+		callback(srcActor)
 	}
+	return nil
+}
 
-	return err
+func (t *manager) GetTransitionDependencies(actorKey actor.Key, status actor.Status) map[actor.Key]actor.Status {
+	return copyDependencies(t.transitionDependenciesByActor[actorKey][status])
 }
 
-// validateTransitionDependencies will perform a DFS to validate that no cycles exist. If a cycle is detected, then
-// an error will be returned.
-func (t *manager) validateTransitionDependencies(srcActor actor.Key, srcStatus actor.Status) error {
-	visited := map[string]bool{}
-	var queue []*ActorStatusKey
-	queue = append(queue, &ActorStatusKey{
-		actorKey: srcActor,
-		status:   srcStatus,
-	})
-	for len(queue) > 0 {
-		curr := queue[0]
-		queue = queue[1:]
-		currKey := curr.String()
-
-		visited[currKey] = true
-		// Add dependencies to the queue
-		dependencies := t.transitionDependenciesByActor[curr.actorKey][curr.status]
-		for depActor, status := range dependencies {
-			key := &ActorStatusKey{
-				actorKey: depActor,
-				status:   status,
-			}
-			if _, ok := visited[key.String()]; ok {
-				return errors.New("already visited")
-			}
-			queue = append(queue, key)
+func (t *manager) GetDependents(actorKey actor.Key, status actor.Status) map[actor.Key]actor.Status {
+	return copyDependencies(t.reverseDependencies[actorKey][status])
+}
+
+func (t *manager) GetAllTransitionDependencies(actorKey actor.Key) map[actor.Status]map[actor.Key]actor.Status {
+	result := map[actor.Status]map[actor.Key]actor.Status{}
+	for status, dependencies := range t.transitionDependenciesByActor[actorKey] {
+		if len(dependencies) > 0 {
+			result[status] = copyDependencies(dependencies)
 		}
 	}
+	return result
+}
+
+// copyDependencies returns a copy of the given dependencies so that callers cannot mutate the internal state of the
+// manager.
+func copyDependencies(dependencies map[actor.Key]actor.Status) map[actor.Key]actor.Status {
+	result := make(map[actor.Key]actor.Status, len(dependencies))
+	for actorKey, status := range dependencies {
+		result[actorKey] = status
+	}
+	return result
+}
+
+// validateTransitionDependency will perform a DFS to validate that adding a dependency from srcActor transitioning to
+// srcStatus on depActor transitioning to depStatus does not introduce a cycle. A cycle exists if srcActor & srcStatus
+// is reachable from depActor & depStatus. If a cycle is detected, then a CycleError is returned.
+func (t *manager) validateTransitionDependency(srcActor actor.Key, srcStatus actor.Status, depActor actor.Key, depStatus actor.Status) error {
+	src := ActorStatusKey{ActorKey: srcActor, Status: srcStatus}
+	dep := ActorStatusKey{ActorKey: depActor, Status: depStatus}
+
+	visited := map[ActorStatusKey]bool{}
+	if path, ok := t.findPath(dep, src, visited); ok {
+		return &CycleError{Path: append([]ActorStatusKey{src}, path...)}
+	}
 	return nil
 }
+
+// findPath returns the path of dependencies from curr to target, inclusive of both, if one exists. Nodes which have
+// already been visited are skipped since they are known to not reach the target.
+func (t *manager) findPath(curr ActorStatusKey, target ActorStatusKey, visited map[ActorStatusKey]bool) ([]ActorStatusKey, bool) {
+	if curr == target {
+		return []ActorStatusKey{curr}, true
+	}
+	if visited[curr] {
+		return nil, false
+	}
+	visited[curr] = true
+
+	for depActor, status := range t.transitionDependenciesByActor[curr.ActorKey][curr.Status] {
+		next := ActorStatusKey{ActorKey: depActor, Status: status}
+		if path, ok := t.findPath(next, target, visited); ok {
+			return append([]ActorStatusKey{curr}, path...), true
+		}
+	}
+	return nil, false
+}
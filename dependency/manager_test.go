@@ -108,7 +108,7 @@ func TestAddTransitionDependency_SameActorCircularDependency(t *testing.T) {
 	mgr := NewManager()
 	err := mgr.AddTransitionDependency(ActorA, SrcStatus, ActorA, SrcStatus)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrDependencyCycle)
 }
 
 // Verify that the code can catch circular dependencies between two actors:
@@ -161,3 +161,172 @@ func TestAddTransitionDependency_NonCircularDependency(t *testing.T) {
 	err = mgr.AddTransitionDependency(ActorC, SrcStatus, ActorD, SrcStatus)
 	assert.NoError(t, err)
 }
+
+func TestRemoveTransitionDependency(t *testing.T) {
+	mgr := NewManager()
+	err := mgr.AddTransitionDependency(SrcActorKey, SrcStatus, DepActorKey, DepStatus)
+	assert.NoError(t, err)
+
+	var notified []actor.Key
+	err = mgr.RemoveTransitionDependency(SrcActorKey, SrcStatus, DepActorKey, DepStatus, func(actorKey actor.Key) {
+		notified = append(notified, actorKey)
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []actor.Key{SrcActorKey}, notified)
+	assert.False(t, mgr.HasTransitionDependencies(SrcActorKey, SrcStatus))
+	assert.Empty(t, mgr.GetDependents(DepActorKey, DepStatus))
+}
+
+func TestRemoveTransitionDependency_RemainingDependencies(t *testing.T) {
+	mgr := NewManager()
+	err := mgr.AddTransitionDependency(SrcActorKey, SrcStatus, DepActorKey, DepStatus)
+	assert.NoError(t, err)
+	err = mgr.AddTransitionDependency(SrcActorKey, SrcStatus, OtherActorKey, DepStatus)
+	assert.NoError(t, err)
+
+	// The callback should not be called since SrcActorKey still depends on OtherActorKey.
+	timesCalled := 0
+	err = mgr.RemoveTransitionDependency(SrcActorKey, SrcStatus, DepActorKey, DepStatus, func(actorKey actor.Key) {
+		timesCalled += 1
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, timesCalled)
+	assert.True(t, mgr.HasTransitionDependencies(SrcActorKey, SrcStatus))
+}
+
+func TestRemoveTransitionDependency_MissingDependency(t *testing.T) {
+	mgr := NewManager()
+	err := mgr.AddTransitionDependency(SrcActorKey, SrcStatus, DepActorKey, DepStatus)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		depActor  actor.Key
+		depStatus actor.Status
+		msg       string
+	}{
+		{depActor: OtherActorKey, depStatus: DepStatus, msg: "return an error when the dependent actor does not exist"},
+		{depActor: DepActorKey, depStatus: MissingStatus, msg: "return an error when the dependent status does not match"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			err := mgr.RemoveTransitionDependency(SrcActorKey, SrcStatus, test.depActor, test.depStatus, func(actor.Key) {
+				assert.Fail(t, "callback should not be called")
+			})
+			assert.ErrorIs(t, err, ErrUnknownDependency)
+		})
+	}
+	assert.True(t, mgr.HasTransitionDependencies(SrcActorKey, SrcStatus))
+}
+
+func TestGetTransitionDependencies(t *testing.T) {
+	mgr := NewManager()
+	err := mgr.AddTransitionDependency(SrcActorKey, SrcStatus, DepActorKey, DepStatus)
+	assert.NoError(t, err)
+	err = mgr.AddTransitionDependency(SrcActorKey, SrcStatus, OtherActorKey, SrcStatus)
+	assert.NoError(t, err)
+
+	deps := mgr.GetTransitionDependencies(SrcActorKey, SrcStatus)
+	assert.Equal(t, map[actor.Key]actor.Status{DepActorKey: DepStatus, OtherActorKey: SrcStatus}, deps)
+
+	// Mutating the result should not affect the manager.
+	delete(deps, DepActorKey)
+	assert.Len(t, mgr.GetTransitionDependencies(SrcActorKey, SrcStatus), 2)
+
+	assert.Empty(t, mgr.GetTransitionDependencies(InvalidActorKey, SrcStatus))
+}
+
+func TestGetDependents(t *testing.T) {
+	mgr := NewManager()
+	err := mgr.AddTransitionDependency(SrcActorKey, SrcStatus, DepActorKey, DepStatus)
+	assert.NoError(t, err)
+	err = mgr.AddTransitionDependency(OtherActorKey, SrcStatus, DepActorKey, DepStatus)
+	assert.NoError(t, err)
+
+	dependents := mgr.GetDependents(DepActorKey, DepStatus)
+	assert.Equal(t, map[actor.Key]actor.Status{SrcActorKey: SrcStatus, OtherActorKey: SrcStatus}, dependents)
+
+	assert.Empty(t, mgr.GetDependents(DepActorKey, MissingStatus))
+}
+
+// Verify that converging paths of different lengths are not mistaken for a cycle. For example:
+//
+//	A -> B -> C -> D
+//	A -----------> D
+func TestAddTransitionDependency_ConvergingPaths(t *testing.T) {
+	mgr := NewManager()
+
+	err := mgr.AddTransitionDependency(ActorA, SrcStatus, ActorB, SrcStatus)
+	assert.NoError(t, err)
+
+	err = mgr.AddTransitionDependency(ActorB, SrcStatus, ActorC, SrcStatus)
+	assert.NoError(t, err)
+
+	err = mgr.AddTransitionDependency(ActorC, SrcStatus, ActorD, SrcStatus)
+	assert.NoError(t, err)
+
+	err = mgr.AddTransitionDependency(ActorA, SrcStatus, ActorD, SrcStatus)
+	assert.NoError(t, err)
+}
+
+// Verify that the CycleError reports the path which forms the cycle:
+// ActorA -> ActorB -> ActorC -> ActorA
+func TestAddTransitionDependency_CycleErrorPath(t *testing.T) {
+	mgr := NewManager()
+
+	err := mgr.AddTransitionDependency(ActorA, SrcStatus, ActorB, DepStatus)
+	assert.NoError(t, err)
+
+	err = mgr.AddTransitionDependency(ActorB, DepStatus, ActorC, DepStatus)
+	assert.NoError(t, err)
+
+	err = mgr.AddTransitionDependency(ActorC, DepStatus, ActorA, SrcStatus)
+
+	var cycleErr *CycleError
+	assert.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, []ActorStatusKey{
+		{ActorKey: ActorC, Status: DepStatus},
+		{ActorKey: ActorA, Status: SrcStatus},
+		{ActorKey: ActorB, Status: DepStatus},
+		{ActorKey: ActorC, Status: DepStatus},
+	}, cycleErr.Path)
+}
+
+// Verify that a rejected dependency does not remove any existing dependencies for the same actor & status.
+func TestAddTransitionDependency_CycleRollback(t *testing.T) {
+	mgr := NewManager()
+
+	err := mgr.AddTransitionDependency(ActorA, SrcStatus, ActorB, SrcStatus)
+	assert.NoError(t, err)
+
+	err = mgr.AddTransitionDependency(ActorB, SrcStatus, ActorC, SrcStatus)
+	assert.NoError(t, err)
+
+	err = mgr.AddTransitionDependency(ActorB, SrcStatus, ActorA, SrcStatus)
+	assert.Error(t, err)
+
+	assert.Equal(t, map[actor.Key]actor.Status{ActorC: SrcStatus}, mgr.GetTransitionDependencies(ActorB, SrcStatus))
+	assert.Empty(t, mgr.GetDependents(ActorA, SrcStatus))
+}
+
+func TestGetAllTransitionDependencies(t *testing.T) {
+	mgr := NewManager()
+	err := mgr.AddTransitionDependency(SrcActorKey, SrcStatus, DepActorKey, DepStatus)
+	assert.NoError(t, err)
+	err = mgr.AddTransitionDependency(SrcActorKey, MissingStatus, OtherActorKey, DepStatus)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[actor.Status]map[actor.Key]actor.Status{
+		SrcStatus:     {DepActorKey: DepStatus},
+		MissingStatus: {OtherActorKey: DepStatus},
+	}, mgr.GetAllTransitionDependencies(SrcActorKey))
+
+	// Statuses whose dependencies have been satisfied are omitted.
+	mgr.NotifyDependenciesOfStatus(DepActorKey, DepStatus, func(actor.Key) {})
+	assert.Equal(t, map[actor.Status]map[actor.Key]actor.Status{
+		MissingStatus: {OtherActorKey: DepStatus},
+	}, mgr.GetAllTransitionDependencies(SrcActorKey))
+	assert.Empty(t, mgr.GetAllTransitionDependencies(DepActorKey))
+}
@@ -7,10 +7,10 @@ import (
 
 // ActorStatusKey is used to determine if cycles exist in the dependency map.
 type ActorStatusKey struct {
-	actorKey actor.Key
-	status   actor.Status
+	ActorKey actor.Key
+	Status   actor.Status
 }
 
 func (a *ActorStatusKey) String() string {
-	return fmt.Sprintf("%s-%s", a.actorKey, a.status)
+	return fmt.Sprintf("%s-%s", a.ActorKey, a.Status)
 }
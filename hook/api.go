@@ -0,0 +1,16 @@
+package hook
+
+import "github.com/strategicpause/slashie/actor"
+
+// Manager keeps track of the hooks to execute when an actor enters or exits a status. Unlike subscriptions, hooks
+// are not removed after they have been handled.
+type Manager interface {
+	// AddEnterHook adds a callback to execute each time the given actor transitions to the given status.
+	AddEnterHook(actorKey actor.Key, status actor.Status, hook Hook)
+	// AddExitHook adds a callback to execute each time the given actor transitions from the given status.
+	AddExitHook(actorKey actor.Key, status actor.Status, hook Hook)
+	// HandleEnterHooks passes each enter hook for the given actor & status to the callback function.
+	HandleEnterHooks(actorKey actor.Key, status actor.Status, callback func(h Hook))
+	// HandleExitHooks passes each exit hook for the given actor & status to the callback function.
+	HandleExitHooks(actorKey actor.Key, status actor.Status, callback func(h Hook))
+}
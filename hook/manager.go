@@ -0,0 +1,46 @@
+package hook
+
+import "github.com/strategicpause/slashie/actor"
+
+type manager struct {
+	// enterHooksForActor
+	enterHooksForActor map[actor.Key]HooksByStatus
+	// exitHooksForActor
+	exitHooksForActor map[actor.Key]HooksByStatus
+}
+
+func NewManager() Manager {
+	return &manager{
+		enterHooksForActor: map[actor.Key]HooksByStatus{},
+		exitHooksForActor:  map[actor.Key]HooksByStatus{},
+	}
+}
+
+func (m *manager) AddEnterHook(actorKey actor.Key, status actor.Status, hook Hook) {
+	addHook(m.enterHooksForActor, actorKey, status, hook)
+}
+
+func (m *manager) AddExitHook(actorKey actor.Key, status actor.Status, hook Hook) {
+	addHook(m.exitHooksForActor, actorKey, status, hook)
+}
+
+func (m *manager) HandleEnterHooks(actorKey actor.Key, status actor.Status, callback func(h Hook)) {
+	for _, hook := range m.enterHooksForActor[actorKey][status] {
+		callback(hook)
+	}
+}
+
+func (m *manager) HandleExitHooks(actorKey actor.Key, status actor.Status, callback func(h Hook)) {
+	for _, hook := range m.exitHooksForActor[actorKey][status] {
+		callback(hook)
+	}
+}
+
+func addHook(hooksForActor map[actor.Key]HooksByStatus, actorKey actor.Key, status actor.Status, hook Hook) {
+	if _, ok := hooksForActor[actorKey]; !ok {
+		hooksForActor[actorKey] = HooksByStatus{}
+	}
+	hooksByStatus := hooksForActor[actorKey]
+
+	hooksByStatus[status] = append(hooksByStatus[status], hook)
+}
@@ -0,0 +1,64 @@
+package hook
+
+import (
+	"testing"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ActorKey        = "ActorKey"
+	InvalidActorKey = "InvalidActorKey"
+
+	Status        = "Status"
+	MissingStatus = "MissingStatus"
+)
+
+type HandleHooksTest struct {
+	actorKey actor.Key
+	status   actor.Status
+	msg      string
+	numHooks int
+}
+
+func TestHandleEnterHooks(t *testing.T) {
+	mgr := NewManager()
+	mgr.AddEnterHook(ActorKey, Status, func() {})
+	mgr.AddEnterHook(ActorKey, Status, func() {})
+	mgr.AddExitHook(ActorKey, MissingStatus, func() {})
+
+	tests := []*HandleHooksTest{
+		{actorKey: InvalidActorKey, status: Status, numHooks: 0,
+			msg: "return no hooks when the actor doesn't exist"},
+		{actorKey: ActorKey, status: MissingStatus, numHooks: 0,
+			msg: "return no hooks when no enter hooks exist for the status"},
+		{actorKey: ActorKey, status: Status, numHooks: 2,
+			msg: "return all enter hooks for the status"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			numTimesInvoked := 0
+			mgr.HandleEnterHooks(test.actorKey, test.status, func(h Hook) {
+				numTimesInvoked += 1
+			})
+
+			assert.Equal(t, test.numHooks, numTimesInvoked)
+		})
+	}
+}
+
+func TestHandleExitHooks(t *testing.T) {
+	mgr := NewManager()
+	mgr.AddExitHook(ActorKey, Status, func() {})
+
+	// Hooks are not removed once handled.
+	for i := 0; i < 2; i++ {
+		numTimesInvoked := 0
+		mgr.HandleExitHooks(ActorKey, Status, func(h Hook) {
+			numTimesInvoked += 1
+		})
+		assert.Equal(t, 1, numTimesInvoked)
+	}
+}
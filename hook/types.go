@@ -0,0 +1,9 @@
+package hook
+
+import "github.com/strategicpause/slashie/actor"
+
+// Hook is a function to execute each time an actor enters or exits a given status.
+type Hook func()
+
+// HooksByStatus
+type HooksByStatus map[actor.Status][]Hook
@@ -97,3 +97,45 @@ func (s *stdOutLogger) Error(message string) {
 func (s *stdOutLogger) Errorf(format string, a ...any) {
 	s.Error(fmt.Sprintf(format, a...))
 }
+
+type prefixLogger struct {
+	logger Logger
+	prefix string
+}
+
+// NewPrefixLogger returns a Logger which adds the given prefix to each message before passing it to the given Logger.
+func NewPrefixLogger(l Logger, prefix string) Logger {
+	return &prefixLogger{logger: l, prefix: prefix}
+}
+
+func (s *prefixLogger) Debugf(format string, a ...any) {
+	s.logger.Debug(s.prefix + fmt.Sprintf(format, a...))
+}
+
+func (s *prefixLogger) Debug(message string) {
+	s.logger.Debug(s.prefix + message)
+}
+
+func (s *prefixLogger) Info(message string) {
+	s.logger.Info(s.prefix + message)
+}
+
+func (s *prefixLogger) Infof(format string, a ...any) {
+	s.logger.Info(s.prefix + fmt.Sprintf(format, a...))
+}
+
+func (s *prefixLogger) Warn(message string) {
+	s.logger.Warn(s.prefix + message)
+}
+
+func (s *prefixLogger) Warnf(format string, a ...any) {
+	s.logger.Warn(s.prefix + fmt.Sprintf(format, a...))
+}
+
+func (s *prefixLogger) Error(message string) {
+	s.logger.Error(s.prefix + message)
+}
+
+func (s *prefixLogger) Errorf(format string, a ...any) {
+	s.logger.Error(s.prefix + fmt.Sprintf(format, a...))
+}
@@ -0,0 +1,14 @@
+package slashie
+
+import "github.com/strategicpause/slashie/actor"
+
+// ActorQuery selects actors through FindActors. Each field which is set must match for an actor to be selected, and
+// the zero ActorQuery selects every actor.
+type ActorQuery struct {
+	// Type selects actors of the given Type.
+	Type actor.Type
+	// KeyPattern selects actors whose Key matches the given glob pattern, using the syntax of path.Match.
+	KeyPattern string
+	// Status selects actors whose known status is the given status, or is nested under it.
+	Status actor.Status
+}
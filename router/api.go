@@ -0,0 +1,23 @@
+package router
+
+import "github.com/strategicpause/slashie/actor"
+
+// Strategy selects the routees which receive each message sent to a Router. A Strategy is called while the Router
+// holds its lock, so it does not need to be safe for concurrent use, but it must not be shared between routers.
+type Strategy interface {
+	// Route returns the routees which should receive the given message. The routees are never empty, and are ordered
+	// by the time at which they joined the pool.
+	Route(message any, routees []actor.Actor) []actor.Actor
+}
+
+// Pool is an actor which forwards messages to the actors of a given Type. Slashie adds each actor of the PoolType
+// to the pool when it is added, and removes it once it reaches its terminal status.
+type Pool interface {
+	actor.Actor
+	// PoolType returns the Type of actor which belongs to the pool.
+	PoolType() actor.Type
+	// AddRoutee adds an actor to the pool. Adding an actor which is already in the pool has no effect.
+	AddRoutee(routee actor.Actor)
+	// RemoveRoutee removes the actor with the given Key from the pool. Returns false if it was not in the pool.
+	RemoveRoutee(actorKey actor.Key) bool
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"github.com/strategicpause/slashie/actor"
+)
+
+var (
+	// ErrNoRoutees is returned when a message is sent to a Router whose pool is empty.
+	ErrNoRoutees = errors.New("no routees")
+)
+
+// NoRouteesError indicates that the router identified by RouterKey has no routees to forward a message to.
+type NoRouteesError struct {
+	RouterKey actor.Key
+}
+
+func (e *NoRouteesError) Error() string {
+	return fmt.Sprintf("router %s has no routees", e.RouterKey)
+}
+
+func (e *NoRouteesError) Is(target error) bool {
+	return target == ErrNoRoutees
+}
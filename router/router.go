@@ -0,0 +1,178 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"github.com/strategicpause/slashie/actor"
+	"sync"
+	"time"
+)
+
+// Router is an actor which forwards each message sent through SendMessage, TrySendMessage or Ask to the actors in its
+// pool, as selected by its Strategy. Messages sent through Notify are handled by the router itself.
+type Router struct {
+	*actor.BasicActor
+	poolType actor.Type
+	strategy Strategy
+
+	mu      sync.Mutex
+	routees []actor.Actor
+	stopped bool
+}
+
+// NewRouter returns a Router which forwards messages to actors of the given poolType. The options are applied to the
+// BasicActor which runs the router's own event loop.
+func NewRouter(routerType actor.Type, routerId actor.Id, poolType actor.Type, strategy Strategy, opts ...actor.BasicActorOpt) *Router {
+	return &Router{
+		BasicActor: actor.NewBasicActor(routerType, routerId, opts...),
+		poolType:   poolType,
+		strategy:   strategy,
+	}
+}
+
+func (r *Router) PoolType() actor.Type {
+	return r.poolType
+}
+
+func (r *Router) AddRoutee(routee actor.Actor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A router never forwards messages to itself.
+	if routee.GetKey() == r.GetKey() || r.indexOf(routee.GetKey()) >= 0 {
+		return
+	}
+	r.routees = append(r.routees, routee)
+}
+
+func (r *Router) RemoveRoutee(actorKey actor.Key) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.indexOf(actorKey)
+	if i < 0 {
+		return false
+	}
+	r.routees = append(r.routees[:i:i], r.routees[i+1:]...)
+	return true
+}
+
+// Routees returns the actors in the pool, in the order in which they joined.
+func (r *Router) Routees() []actor.Actor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]actor.Actor(nil), r.routees...)
+}
+
+// indexOf returns the index of the routee with the given Key, or -1 if it is not in the pool. The lock must be held.
+func (r *Router) indexOf(actorKey actor.Key) int {
+	for i, routee := range r.routees {
+		if routee.GetKey() == actorKey {
+			return i
+		}
+	}
+	return -1
+}
+
+// SendMessage forwards the message to the routees selected by the Strategy. Routees which have stopped are removed
+// from the pool, and the message is routed again.
+func (r *Router) SendMessage(message any) error {
+	return r.forward(message, false, func(routee actor.Actor, message any) error {
+		return routee.SendMessage(message)
+	})
+}
+
+// TrySendMessage forwards the message in the same way as SendMessage, without blocking on the routees' mailboxes.
+func (r *Router) TrySendMessage(message any) error {
+	return r.forward(message, false, func(routee actor.Actor, message any) error {
+		return routee.TrySendMessage(message)
+	})
+}
+
+// TrySendMessageUntil forwards the message in the same way as SendMessage, waiting until the deadline for room in the
+// routees' mailboxes.
+func (r *Router) TrySendMessageUntil(deadline time.Time, message any) error {
+	return r.forward(message, false, func(routee actor.Actor, message any) error {
+		return routee.TrySendMessageUntil(deadline, message)
+	})
+}
+
+// Ask forwards the message to a single routee selected by the Strategy, and returns its reply. If the Strategy
+// selects several routees, as Broadcast does, then only the first of them is asked.
+func (r *Router) Ask(ctx context.Context, message any) (any, error) {
+	var reply any
+	err := r.forward(message, true, func(routee actor.Actor, message any) error {
+		var err error
+		reply, err = routee.Ask(ctx, message)
+		return err
+	})
+	return reply, err
+}
+
+// forward passes the message to each selected routee through send, until the message has been delivered or the pool
+// is empty. The error from the first routee which could not receive the message is returned. If single is true, the
+// message is only passed to the first selected routee.
+func (r *Router) forward(message any, single bool, send func(routee actor.Actor, message any) error) error {
+	// Each routee receives the same Envelope, so that the message has a single Id.
+	message = actor.Seal(message)
+	for {
+		routees, err := r.route(message)
+		if err != nil {
+			return err
+		}
+		if single {
+			routees = routees[:1]
+		}
+
+		var firstErr error
+		delivered := false
+		for _, routee := range routees {
+			err := send(routee, message)
+			switch {
+			case err == nil:
+				delivered = true
+			case errors.Is(err, actor.ErrActorStopped):
+				r.RemoveRoutee(routee.GetKey())
+			case firstErr == nil:
+				firstErr = err
+			}
+		}
+		if delivered || firstErr != nil {
+			return firstErr
+		}
+	}
+}
+
+// route returns the routees which should receive the message. Once the router has stopped, an ActorStoppedError is
+// returned, and a NoRouteesError is returned if the pool is empty, in which case the message is a dead letter.
+func (r *Router) route(message any) ([]actor.Actor, error) {
+	r.mu.Lock()
+	stopped := r.stopped
+	var routees []actor.Actor
+	if !stopped && len(r.routees) > 0 {
+		routees = r.strategy.Route(actor.EnvelopeOf(message).Message, r.routees)
+	}
+	r.mu.Unlock()
+
+	var err error
+	switch {
+	case stopped:
+		err = &actor.ActorStoppedError{ActorKey: r.GetKey()}
+	case len(routees) == 0:
+		err = &NoRouteesError{RouterKey: r.GetKey()}
+	default:
+		return routees, nil
+	}
+	r.DeadLetter(message, err)
+	return nil, err
+}
+
+// Stop stops the router from forwarding messages, and then stops its event loop.
+func (r *Router) Stop() {
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+
+	r.BasicActor.Stop()
+}
@@ -0,0 +1,125 @@
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	RouterType = actor.Type("Router")
+	RouterId   = actor.Id("RouterA")
+)
+
+// newWorker returns a started actor which passes each string message it receives to the given channel, along with its
+// own Id.
+func newWorker(id actor.Id, received chan<- actor.Id) *actor.BasicActor {
+	worker := actor.NewBasicActor(WorkerType, id)
+	worker.RegisterMessageHandler("", func(message any) {
+		received <- id
+	})
+	actor.HandleReply(worker, func(message int) (actor.Id, error) {
+		return id, nil
+	})
+	return worker
+}
+
+func TestRouter_SendMessage(t *testing.T) {
+	received := make(chan actor.Id, 10)
+	r := NewRouter(RouterType, RouterId, WorkerType, NewRoundRobin())
+	r.AddRoutee(newWorker("A", received))
+	r.AddRoutee(newWorker("B", received))
+
+	assert.NoError(t, r.SendMessage("first"))
+	assert.Equal(t, actor.Id("A"), <-received)
+	assert.NoError(t, r.TrySendMessage("second"))
+	assert.Equal(t, actor.Id("B"), <-received)
+
+	reply, err := r.Ask(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, actor.Id("A"), reply)
+}
+
+func TestRouter_Broadcast(t *testing.T) {
+	received := make(chan actor.Id, 10)
+	r := NewRouter(RouterType, RouterId, WorkerType, NewBroadcast())
+	r.AddRoutee(newWorker("A", received))
+	r.AddRoutee(newWorker("B", received))
+
+	assert.NoError(t, r.SendMessage("message"))
+	assert.ElementsMatch(t, []actor.Id{"A", "B"}, []actor.Id{<-received, <-received})
+
+	// Only the first routee is asked.
+	reply, err := r.Ask(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, actor.Id("A"), reply)
+}
+
+func TestRouter_AddRemoveRoutee(t *testing.T) {
+	received := make(chan actor.Id, 10)
+	r := NewRouter(RouterType, RouterId, WorkerType, NewRoundRobin())
+	workerA := newWorker("A", received)
+
+	r.AddRoutee(workerA)
+	r.AddRoutee(workerA)
+	// A router never forwards messages to itself.
+	r.AddRoutee(r)
+	assert.Equal(t, []actor.Actor{workerA}, r.Routees())
+
+	assert.True(t, r.RemoveRoutee(workerA.GetKey()))
+	assert.False(t, r.RemoveRoutee(workerA.GetKey()))
+	assert.Empty(t, r.Routees())
+}
+
+func TestRouter_NoRoutees(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	r := NewRouter(RouterType, RouterId, WorkerType, NewRoundRobin(), actor.WithClock(c))
+	deadLetters := make(chan actor.DeadLetter, 1)
+	r.OnDeadLetter(func(deadLetter actor.DeadLetter) {
+		deadLetters <- deadLetter
+	})
+
+	err := r.SendMessage("message")
+	var noRouteesErr *NoRouteesError
+	assert.ErrorAs(t, err, &noRouteesErr)
+	assert.ErrorIs(t, err, ErrNoRoutees)
+	assert.Equal(t, r.GetKey(), noRouteesErr.RouterKey)
+
+	deadLetter := <-deadLetters
+	assert.Equal(t, "message", deadLetter.Message)
+	assert.ErrorIs(t, deadLetter.Reason, ErrNoRoutees)
+	assert.Equal(t, c.Now(), deadLetter.Timestamp)
+}
+
+func TestRouter_StoppedRoutee(t *testing.T) {
+	received := make(chan actor.Id, 10)
+	r := NewRouter(RouterType, RouterId, WorkerType, NewRoundRobin())
+	workerA := newWorker("A", received)
+	r.AddRoutee(workerA)
+	r.AddRoutee(newWorker("B", received))
+
+	workerA.Stop()
+	workerA.Wait()
+
+	// The stopped routee is removed, and the message is sent to the next routee instead.
+	assert.NoError(t, r.SendMessage("message"))
+	assert.Equal(t, actor.Id("B"), <-received)
+	assert.Len(t, r.Routees(), 1)
+}
+
+func TestRouter_Stop(t *testing.T) {
+	received := make(chan actor.Id, 10)
+	r := NewRouter(RouterType, RouterId, WorkerType, NewRoundRobin())
+	r.AddRoutee(newWorker("A", received))
+
+	r.Stop()
+	r.Wait()
+
+	err := r.SendMessage("message")
+	assert.ErrorIs(t, err, actor.ErrActorStopped)
+	assert.Empty(t, received)
+}
@@ -0,0 +1,157 @@
+package router
+
+import (
+	"fmt"
+	"github.com/strategicpause/slashie/actor"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultReplicas is the number of points on the hash ring for each routee of a consistent hashing Strategy.
+	DefaultReplicas = 100
+)
+
+type roundRobin struct {
+	next int
+}
+
+// NewRoundRobin returns a Strategy which sends each message to the next routee in turn.
+func NewRoundRobin() Strategy {
+	return &roundRobin{}
+}
+
+func (s *roundRobin) Route(message any, routees []actor.Actor) []actor.Actor {
+	routee := routees[s.next%len(routees)]
+	s.next = (s.next + 1) % len(routees)
+	return []actor.Actor{routee}
+}
+
+type random struct {
+	rand *rand.Rand
+}
+
+// NewRandom returns a Strategy which sends each message to a routee chosen at random.
+func NewRandom() Strategy {
+	return &random{rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+func (s *random) Route(message any, routees []actor.Actor) []actor.Actor {
+	return []actor.Actor{routees[s.rand.Intn(len(routees))]}
+}
+
+type leastLoaded struct{}
+
+// NewLeastLoaded returns a Strategy which sends each message to the routee with the fewest messages in its mailbox.
+// Ties are broken in favour of the routee which joined the pool first. Routees which do not implement
+// actor.MailboxLenReporter are treated as having an empty mailbox.
+func NewLeastLoaded() Strategy {
+	return &leastLoaded{}
+}
+
+func (s *leastLoaded) Route(message any, routees []actor.Actor) []actor.Actor {
+	routee, minLen := routees[0], actor.MailboxLen(routees[0])
+	for _, r := range routees[1:] {
+		if l := actor.MailboxLen(r); l < minLen {
+			routee, minLen = r, l
+		}
+	}
+	return []actor.Actor{routee}
+}
+
+type broadcast struct{}
+
+// NewBroadcast returns a Strategy which sends each message to every routee.
+func NewBroadcast() Strategy {
+	return &broadcast{}
+}
+
+func (s *broadcast) Route(message any, routees []actor.Actor) []actor.Actor {
+	return append([]actor.Actor(nil), routees...)
+}
+
+type consistentHash struct {
+	keyFunc  func(message any) string
+	replicas int
+	// ring is the sorted list of points on the hash ring, each of which belongs to the routee in owners.
+	ring   []uint32
+	owners map[uint32]actor.Key
+	// members are the keys of the routees which the ring was built from.
+	members []actor.Key
+}
+
+// NewConsistentHash returns a Strategy which sends all messages with the same key, as returned by keyFunc, to the same
+// routee. When routees join or leave the pool, only the keys belonging to those routees move. Each routee is placed on
+// the hash ring replicas times, or DefaultReplicas times if replicas is not positive.
+func NewConsistentHash(keyFunc func(message any) string, replicas int) Strategy {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+	return &consistentHash{keyFunc: keyFunc, replicas: replicas}
+}
+
+func (s *consistentHash) Route(message any, routees []actor.Actor) []actor.Actor {
+	s.buildRing(routees)
+
+	h := hash(s.keyFunc(message))
+	i := sort.Search(len(s.ring), func(i int) bool {
+		return s.ring[i] >= h
+	})
+	if i == len(s.ring) {
+		i = 0
+	}
+	owner := s.owners[s.ring[i]]
+	for _, routee := range routees {
+		if routee.GetKey() == owner {
+			return []actor.Actor{routee}
+		}
+	}
+	return nil
+}
+
+// buildRing rebuilds the hash ring if the routees have changed since it was last built.
+func (s *consistentHash) buildRing(routees []actor.Actor) {
+	if s.isMembership(routees) {
+		return
+	}
+	s.ring = make([]uint32, 0, len(routees)*s.replicas)
+	s.owners = make(map[uint32]actor.Key, len(routees)*s.replicas)
+	s.members = make([]actor.Key, 0, len(routees))
+	for _, routee := range routees {
+		actorKey := routee.GetKey()
+		s.members = append(s.members, actorKey)
+		for i := 0; i < s.replicas; i++ {
+			h := hash(fmt.Sprintf("%s#%d", actorKey, i))
+			// Collisions are resolved in favour of the routee which was placed on the ring first.
+			if _, ok := s.owners[h]; ok {
+				continue
+			}
+			s.owners[h] = actorKey
+			s.ring = append(s.ring, h)
+		}
+	}
+	sort.Slice(s.ring, func(i, j int) bool {
+		return s.ring[i] < s.ring[j]
+	})
+}
+
+// isMembership returns true if the ring was built from the given routees.
+func (s *consistentHash) isMembership(routees []actor.Actor) bool {
+	if len(routees) != len(s.members) {
+		return false
+	}
+	for i, routee := range routees {
+		if routee.GetKey() != s.members[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func hash(key string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum32()
+}
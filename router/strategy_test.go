@@ -0,0 +1,107 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	WorkerType = actor.Type("Worker")
+)
+
+// newRoutees returns unstarted actors, so that messages remain in their mailboxes.
+func newRoutees(ids ...actor.Id) []actor.Actor {
+	var routees []actor.Actor
+	for _, id := range ids {
+		routees = append(routees, actor.NewBasicActor(WorkerType, id, actor.WithDeferredStart()))
+	}
+	return routees
+}
+
+func TestRoundRobin(t *testing.T) {
+	routees := newRoutees("A", "B", "C")
+	strategy := NewRoundRobin()
+
+	var selected []actor.Actor
+	for i := 0; i < 4; i++ {
+		selected = append(selected, strategy.Route(i, routees)...)
+	}
+	assert.Equal(t, []actor.Actor{routees[0], routees[1], routees[2], routees[0]}, selected)
+}
+
+func TestRandom(t *testing.T) {
+	routees := newRoutees("A", "B", "C")
+	strategy := NewRandom()
+
+	for i := 0; i < 10; i++ {
+		selected := strategy.Route(i, routees)
+		assert.Len(t, selected, 1)
+		assert.Contains(t, routees, selected[0])
+	}
+}
+
+func TestLeastLoaded(t *testing.T) {
+	routees := newRoutees("A", "B", "C")
+	strategy := NewLeastLoaded()
+
+	// Ties are broken in favour of the first routee.
+	assert.Equal(t, []actor.Actor{routees[0]}, strategy.Route(nil, routees))
+
+	routees[0].Notify(func() {})
+	routees[1].Notify(func() {})
+	assert.Equal(t, []actor.Actor{routees[2]}, strategy.Route(nil, routees))
+}
+
+func TestBroadcast(t *testing.T) {
+	routees := newRoutees("A", "B", "C")
+	strategy := NewBroadcast()
+
+	assert.Equal(t, routees, strategy.Route(nil, routees))
+}
+
+func TestConsistentHash(t *testing.T) {
+	routees := newRoutees("A", "B", "C", "D")
+	strategy := NewConsistentHash(func(message any) string {
+		return message.(string)
+	}, 0)
+
+	keys := []string{"apple", "banana", "cherry", "damson", "elderberry", "fig", "grape", "huckleberry"}
+	owners := map[string]actor.Actor{}
+	for _, key := range keys {
+		selected := strategy.Route(key, routees)
+		assert.Len(t, selected, 1)
+		owners[key] = selected[0]
+		// The same key is always sent to the same routee.
+		assert.Equal(t, selected, strategy.Route(key, routees))
+	}
+
+	// Only the keys which belonged to the removed routee move.
+	remaining := append(append([]actor.Actor(nil), routees[:1]...), routees[2:]...)
+	for _, key := range keys {
+		selected := strategy.Route(key, remaining)
+		if owners[key] != routees[1] {
+			assert.Equal(t, owners[key], selected[0])
+		} else {
+			assert.NotEqual(t, routees[1], selected[0])
+		}
+	}
+}
+
+func TestConsistentHash_Envelope(t *testing.T) {
+	routees := newRoutees("A", "B")
+	r := NewRouter("Router", "RouterA", WorkerType, NewConsistentHash(func(message any) string {
+		return message.(string)
+	}, 10))
+	for _, routee := range routees {
+		r.AddRoutee(routee)
+		routee.Start()
+		routee.RegisterMessageHandler("", func(message any) {})
+	}
+
+	// The key is taken from the message inside the Envelope.
+	_, err := r.Ask(context.Background(), actor.Envelope{Message: "apple"})
+	assert.NoError(t, err)
+}
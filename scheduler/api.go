@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"github.com/strategicpause/slashie/actor"
+	"time"
+)
+
+// Manager keeps track of messages which are scheduled to be delivered to actors in the future.
+type Manager interface {
+	// Schedule adds a message to deliver to the given actor at the given time. If interval is non-zero, then the
+	// message is delivered every interval thereafter until it is cancelled.
+	Schedule(actorKey actor.Key, at time.Time, interval time.Duration, message any) Id
+	// Cancel removes the scheduled message with the given Id. Returns false if there is no such message.
+	Cancel(id Id) bool
+	// CancelAll removes all scheduled messages for the given actor.
+	CancelAll(actorKey actor.Key)
+	// Next returns the time at which the next message is due. The second return value is false if there are no
+	// scheduled messages.
+	Next() (time.Time, bool)
+	// HandleDueMessages passes each message which is due at the given time to the callback function, in the order
+	// in which they are due. Periodic messages are rescheduled, skipping any deliveries which have been missed.
+	HandleDueMessages(now time.Time, callback func(actorKey actor.Key, message any))
+}
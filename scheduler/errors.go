@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInvalidInterval is returned when scheduling a periodic message with a non-positive interval.
+	ErrInvalidInterval = errors.New("invalid interval")
+)
+
+// InvalidIntervalError indicates that a periodic message cannot be delivered every Interval.
+type InvalidIntervalError struct {
+	Interval time.Duration
+}
+
+func (e *InvalidIntervalError) Error() string {
+	return fmt.Sprintf("interval must be positive, got %s", e.Interval)
+}
+
+func (e *InvalidIntervalError) Is(target error) bool {
+	return target == ErrInvalidInterval
+}
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"container/heap"
+	"github.com/strategicpause/slashie/actor"
+	"time"
+)
+
+type manager struct {
+	// messages is the heap of all scheduled messages.
+	messages messageHeap
+	// messagesById
+	messagesById map[Id]*scheduledMessage
+	// nextId is used to assign each scheduled message a unique Id.
+	nextId Id
+}
+
+func NewManager() Manager {
+	return &manager{
+		messagesById: map[Id]*scheduledMessage{},
+	}
+}
+
+func (m *manager) Schedule(actorKey actor.Key, at time.Time, interval time.Duration, message any) Id {
+	m.nextId += 1
+	scheduled := &scheduledMessage{
+		id:       m.nextId,
+		actorKey: actorKey,
+		at:       at,
+		interval: interval,
+		message:  message,
+	}
+	heap.Push(&m.messages, scheduled)
+	m.messagesById[scheduled.id] = scheduled
+
+	return scheduled.id
+}
+
+func (m *manager) Cancel(id Id) bool {
+	scheduled, ok := m.messagesById[id]
+	if !ok {
+		return false
+	}
+	heap.Remove(&m.messages, scheduled.index)
+	delete(m.messagesById, id)
+
+	return true
+}
+
+func (m *manager) CancelAll(actorKey actor.Key) {
+	for id, scheduled := range m.messagesById {
+		if scheduled.actorKey == actorKey {
+			m.Cancel(id)
+		}
+	}
+}
+
+func (m *manager) Next() (time.Time, bool) {
+	if len(m.messages) == 0 {
+		return time.Time{}, false
+	}
+	return m.messages[0].at, true
+}
+
+func (m *manager) HandleDueMessages(now time.Time, callback func(actorKey actor.Key, message any)) {
+	var due []*scheduledMessage
+	for len(m.messages) > 0 && !m.messages[0].at.After(now) {
+		scheduled := heap.Pop(&m.messages).(*scheduledMessage)
+		due = append(due, scheduled)
+		if scheduled.interval == 0 {
+			delete(m.messagesById, scheduled.id)
+		}
+	}
+	// Periodic messages are rescheduled before the callbacks are invoked, so that they may be cancelled from within
+	// the callback.
+	for _, scheduled := range due {
+		if scheduled.interval == 0 {
+			continue
+		}
+		missed := now.Sub(scheduled.at) / scheduled.interval
+		scheduled.at = scheduled.at.Add((missed + 1) * scheduled.interval)
+		heap.Push(&m.messages, scheduled)
+	}
+	for _, scheduled := range due {
+		callback(scheduled.actorKey, scheduled.message)
+	}
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ActorKeyA = "ActorA"
+	ActorKeyB = "ActorB"
+)
+
+type delivery struct {
+	actorKey actor.Key
+	message  any
+}
+
+func handleDueMessages(m Manager, now time.Time) []delivery {
+	var deliveries []delivery
+	m.HandleDueMessages(now, func(actorKey actor.Key, message any) {
+		deliveries = append(deliveries, delivery{actorKey: actorKey, message: message})
+	})
+	return deliveries
+}
+
+func TestHandleDueMessages(t *testing.T) {
+	start := time.Unix(0, 0)
+	m := NewManager()
+
+	_, ok := m.Next()
+	assert.False(t, ok)
+
+	m.Schedule(ActorKeyA, start.Add(2*time.Second), 0, "second")
+	m.Schedule(ActorKeyB, start.Add(time.Second), 0, "first")
+	m.Schedule(ActorKeyA, start.Add(2*time.Second), 0, "third")
+
+	next, ok := m.Next()
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(time.Second), next)
+
+	assert.Empty(t, handleDueMessages(m, start))
+	// Messages due at the same time are delivered in the order in which they were scheduled.
+	assert.Equal(t, []delivery{
+		{ActorKeyB, "first"},
+		{ActorKeyA, "second"},
+		{ActorKeyA, "third"},
+	}, handleDueMessages(m, start.Add(2*time.Second)))
+
+	_, ok = m.Next()
+	assert.False(t, ok)
+}
+
+func TestHandleDueMessages_Periodic(t *testing.T) {
+	start := time.Unix(0, 0)
+	m := NewManager()
+
+	id := m.Schedule(ActorKeyA, start.Add(time.Second), time.Second, "tick")
+
+	assert.Equal(t, []delivery{{ActorKeyA, "tick"}}, handleDueMessages(m, start.Add(time.Second)))
+	next, _ := m.Next()
+	assert.Equal(t, start.Add(2*time.Second), next)
+
+	// Missed deliveries are skipped.
+	assert.Equal(t, []delivery{{ActorKeyA, "tick"}}, handleDueMessages(m, start.Add(4500*time.Millisecond)))
+	next, _ = m.Next()
+	assert.Equal(t, start.Add(5*time.Second), next)
+
+	assert.True(t, m.Cancel(id))
+	assert.False(t, m.Cancel(id))
+	assert.Empty(t, handleDueMessages(m, start.Add(time.Minute)))
+}
+
+func TestCancel(t *testing.T) {
+	start := time.Unix(0, 0)
+	m := NewManager()
+
+	id := m.Schedule(ActorKeyA, start.Add(time.Second), 0, "a1")
+	m.Schedule(ActorKeyA, start.Add(2*time.Second), time.Second, "a2")
+	m.Schedule(ActorKeyB, start.Add(3*time.Second), 0, "b1")
+
+	assert.True(t, m.Cancel(id))
+	m.CancelAll(ActorKeyA)
+
+	assert.Equal(t, []delivery{{ActorKeyB, "b1"}}, handleDueMessages(m, start.Add(time.Minute)))
+	// A delivered message can no longer be cancelled.
+	assert.False(t, m.Cancel(id))
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"github.com/strategicpause/slashie/actor"
+	"time"
+)
+
+// Id uniquely identifies a scheduled message.
+type Id uint64
+
+// Handle allows a scheduled message to be cancelled.
+type Handle interface {
+	// Cancel prevents any further deliveries of the scheduled message. It returns false if the message has already
+	// been delivered, or has already been cancelled.
+	Cancel() bool
+}
+
+// scheduledMessage is a message which will be delivered to an actor at a given time. If interval is non-zero, the
+// message is delivered repeatedly.
+type scheduledMessage struct {
+	id       Id
+	actorKey actor.Key
+	at       time.Time
+	interval time.Duration
+	message  any
+	// index is the position of the scheduledMessage in the heap.
+	index int
+}
+
+// messageHeap is a min-heap of scheduled messages ordered by delivery time. Messages scheduled for the same time are
+// ordered by Id, so that they are delivered in the order in which they were scheduled.
+type messageHeap []*scheduledMessage
+
+func (h messageHeap) Len() int {
+	return len(h)
+}
+
+func (h messageHeap) Less(i, j int) bool {
+	if h[i].at.Equal(h[j].at) {
+		return h[i].id < h[j].id
+	}
+	return h[i].at.Before(h[j].at)
+}
+
+func (h messageHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *messageHeap) Push(x any) {
+	m := x.(*scheduledMessage)
+	m.index = len(*h)
+	*h = append(*h, m)
+}
+
+func (h *messageHeap) Pop() any {
+	old := *h
+	n := len(old)
+	m := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return m
+}
@@ -1,16 +1,29 @@
 package slashie
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/clock"
+	"github.com/strategicpause/slashie/deadletter"
 	"github.com/strategicpause/slashie/dependency"
+	"github.com/strategicpause/slashie/hook"
 	"github.com/strategicpause/slashie/logger"
+	"github.com/strategicpause/slashie/router"
+	"github.com/strategicpause/slashie/scheduler"
 	"github.com/strategicpause/slashie/subscription"
+	"github.com/strategicpause/slashie/timer"
+	"github.com/strategicpause/slashie/topic"
 	"github.com/strategicpause/slashie/transition"
+	"sync"
+	"time"
 )
 
 const (
 	DefaultMailboxSize = 100
+	// TopicHeader is the Envelope header which holds the topic that a message was published to.
+	TopicHeader = "slashie-topic"
 )
 
 // message is the internal slashie type used to represent a slashie message.
@@ -25,8 +38,32 @@ type slashie struct {
 	subscriptionManager subscription.Manager
 	transitionManager   transition.Manager
 	dependencyManager   dependency.Manager
+	hookManager         hook.Manager
+	timerManager        timer.Manager
+	schedulerManager    scheduler.Manager
+	deadLetterManager   deadletter.Manager
+	topicManager        topic.Manager
+	clock               clock.Clock
+	// scheduleTimer fires when the next scheduled message is due at scheduledAt.
+	scheduleTimer clock.Timer
+	scheduledAt   time.Time
+	// addedActors holds each actor by Key as soon as AddActor is called, so that TrySendMessage can find an actor
+	// without waiting on the coordinator.
+	addedActors sync.Map
+	// deliveries holds the published and due scheduled messages for each actor until they are delivered.
+	deliveries          *deliveryQueues
 	logger              logger.Logger
 	mailbox             mailbox
+	pendingStatusPolicy actor.PendingStatusPolicy
+	multiHopTransitions bool
+	// pools are the routers for each Type of actor, which are kept up to date as actors are added and removed.
+	pools map[actor.Type][]router.Pool
+	// exitedStatuses are the statuses which each actor has run the exit hooks for since its known status last
+	// changed, so that they only run once even if a transition is cancelled and started again.
+	exitedStatuses map[actor.Key]map[actor.Status]struct{}
+	// evaluatingGuards holds the actors whose transition guards are being evaluated. Status updates for these actors
+	// are queued until the result of the guards has been applied.
+	evaluatingGuards map[actor.Key]struct{}
 }
 
 type Opt func(s *slashie)
@@ -37,6 +74,30 @@ func WithMailboxSize(size int) Opt {
 	}
 }
 
+// WithPendingStatusPolicy determines how status updates are handled for an actor which is already transitioning.
+// By default, status updates are queued and processed in order once the current transition has completed.
+func WithPendingStatusPolicy(policy actor.PendingStatusPolicy) Opt {
+	return func(s *slashie) {
+		s.pendingStatusPolicy = policy
+	}
+}
+
+// WithMultiHopTransitions allows UpdateStatus to accept statuses which are not directly reachable from an actor's
+// current status. The actor will transition through the shortest path of registered transitions, stopping at the
+// first transition which fails.
+func WithMultiHopTransitions() Opt {
+	return func(s *slashie) {
+		s.multiHopTransitions = true
+	}
+}
+
+// WithClock sets the clock used to schedule timed transitions and messages. By default, the system clock is used.
+func WithClock(c clock.Clock) Opt {
+	return func(s *slashie) {
+		s.clock = c
+	}
+}
+
 func WithLogger(l logger.Logger) Opt {
 	return func(s *slashie) {
 		s.logger = l
@@ -44,7 +105,12 @@ func WithLogger(l logger.Logger) Opt {
 }
 
 func NewSlashie(opts ...Opt) Slashie {
-	s := &slashie{}
+	s := &slashie{
+		pools:            map[actor.Type][]router.Pool{},
+		exitedStatuses:   map[actor.Key]map[actor.Status]struct{}{},
+		evaluatingGuards: map[actor.Key]struct{}{},
+		deliveries:       newDeliveryQueues(),
+	}
 
 	for _, opt := range opts {
 		opt(s)
@@ -55,17 +121,35 @@ func NewSlashie(opts ...Opt) Slashie {
 		s.actorRegistry = actor.NewRegistry()
 	}
 	if s.actorStatusManager == nil {
-		s.actorStatusManager = actor.NewStatusManager()
+		s.actorStatusManager = actor.NewStatusManager(actor.WithPendingStatusPolicy(s.pendingStatusPolicy))
 	}
 	if s.subscriptionManager == nil {
 		s.subscriptionManager = subscription.NewManager()
 	}
 	if s.transitionManager == nil {
-		s.transitionManager = transition.NewManager()
+		s.transitionManager = transition.NewManager(transition.WithLineageFunc(s.actorStatusManager.GetLineage))
 	}
 	if s.dependencyManager == nil {
 		s.dependencyManager = dependency.NewManager()
 	}
+	if s.hookManager == nil {
+		s.hookManager = hook.NewManager()
+	}
+	if s.clock == nil {
+		s.clock = clock.NewRealClock()
+	}
+	if s.timerManager == nil {
+		s.timerManager = timer.NewManager(s.clock)
+	}
+	if s.schedulerManager == nil {
+		s.schedulerManager = scheduler.NewManager()
+	}
+	if s.deadLetterManager == nil {
+		s.deadLetterManager = deadletter.NewManager()
+	}
+	if s.topicManager == nil {
+		s.topicManager = topic.NewManager()
+	}
 	if s.logger == nil {
 		s.logger = logger.NewNullOutputLogger()
 	}
@@ -85,78 +169,252 @@ func (s *slashie) init() {
 }
 
 func (s *slashie) AddActor(actor actor.Actor, initStatus actor.Status, terminalStatus actor.Status) {
+	// The dead letter handler is set before the actor can be found by TrySendMessage, which does not wait for the
+	// actor to be registered by the coordinator.
+	actor.OnDeadLetter(s.publishDeadLetter)
+	s.addedActors.Store(actor.GetKey(), actor)
 	s.mailbox <- func() {
 		actorKey := s.actorRegistry.RegisterActor(actor)
 		/*actor.RegisterMessageHandler(subscription.SubscriptionType, func(s any) {
 			s.(subscription.Subscription)()
 		})*/
 		s.actorStatusManager.InitializeActor(actorKey, initStatus, terminalStatus)
+		s.addToPools(actor)
+		// Actors created with a deferred start begin processing messages once they have been added.
+		actor.Start()
+	}
+}
+
+// addToPools adds the given actor to each router of its Type. If the actor is itself a router, then the actors of its
+// PoolType which have not reached their terminal status are added to its pool.
+func (s *slashie) addToPools(a actor.Actor) {
+	for _, pool := range s.pools[a.GetType()] {
+		pool.AddRoutee(a)
+	}
+
+	pool, ok := a.(router.Pool)
+	if !ok {
+		return
+	}
+	s.pools[pool.PoolType()] = append(s.pools[pool.PoolType()], pool)
+	for _, routee := range s.actorRegistry.ListByType(pool.PoolType()) {
+		routeeKey := routee.GetKey()
+		if s.actorStatusManager.GetKnownStatus(routeeKey) != s.actorStatusManager.GetTerminalStatus(routeeKey) {
+			pool.AddRoutee(routee)
+		}
+	}
+}
+
+// removeFromPools removes the actor with the given Key from each router, and stops routing to the actor if it is itself
+// a router.
+func (s *slashie) removeFromPools(actorKey actor.Key) {
+	for poolType, pools := range s.pools {
+		remaining := pools[:0]
+		for _, pool := range pools {
+			pool.RemoveRoutee(actorKey)
+			if pool.GetKey() != actorKey {
+				remaining = append(remaining, pool)
+			}
+		}
+		s.pools[poolType] = remaining
 	}
 }
 
 func (s *slashie) UpdateStatus(a actor.Actor, status actor.Status) error {
-	errChan := make(chan error)
+	// The result may be sent after the caller's message has been processed if guards need to be evaluated first.
+	errChan := make(chan error, 1)
 	s.mailbox <- func() {
-		defer close(errChan)
-
 		actorKey := a.GetKey()
 		if ok := s.actorRegistry.IsRegistered(a); !ok {
-			errChan <- fmt.Errorf("unknown actor %s", actorKey)
+			errChan <- &actor.UnknownActorError{ActorKey: actorKey}
 			return
 		}
 
-		errChan <- s.updateStatus(actorKey, status)
+		s.updateStatus(actorKey, status, func(err error) {
+			errChan <- err
+		})
 	}
 	return <-errChan
 }
 
-func (s *slashie) updateStatus(actorKey actor.Key, desiredStatus actor.Status) error {
+// updateStatusAsync requests a status update for the given actor without waiting for the result, which is logged
+// by the actor if it fails. Updates are applied in the order in which they were requested.
+func (s *slashie) updateStatusAsync(a actor.Actor, status actor.Status) {
+	s.mailbox <- func() {
+		actorKey := a.GetKey()
+		if ok := s.actorRegistry.IsRegistered(a); !ok {
+			a.GetLogger().Errorf("Could not update status to %s: %s", status, &actor.UnknownActorError{ActorKey: actorKey})
+			return
+		}
+
+		s.updateStatus(actorKey, status, func(err error) {
+			if err != nil {
+				a.GetLogger().Errorf("Could not update status to %s: %s", status, err)
+			}
+		})
+	}
+}
+
+// updateStatus will attempt to set the desired status for the given actor. The result is passed to done once the
+// desired status has either been set or rejected.
+func (s *slashie) updateStatus(actorKey actor.Key, desiredStatus actor.Status, done func(error)) {
+	// While guards are being evaluated, the outcome of this update depends on their result, so it is always queued.
+	_, evaluatingGuards := s.evaluatingGuards[actorKey]
 	currentDesiredStatus := s.actorStatusManager.GetDesiredStatus(actorKey)
-	if currentDesiredStatus == desiredStatus {
+	if currentDesiredStatus == desiredStatus && !evaluatingGuards {
 		s.logger.Debugf("%s desired status is already set to %s. Skipping update.", actorKey, desiredStatus)
-		return nil
+		done(nil)
+		return
 	}
 	currentKnownStatus := s.actorStatusManager.GetKnownStatus(actorKey)
-	if currentKnownStatus == desiredStatus {
+	if currentKnownStatus == desiredStatus && !evaluatingGuards {
 		s.logger.Debugf("%s known status is already set to %s. Skipping update.", actorKey, desiredStatus)
-		return nil
+		done(nil)
+		return
 	}
-	// Check to see if the current actor is already undergoing a transition. If so, then let's revisit this later.
-	if currentDesiredStatus != currentKnownStatus {
-		s.mailbox <- func() {
+	// Check to see if the current actor is already undergoing a transition, or evaluating the guards for one. If so,
+	// then the update will be handled once the transition has completed.
+	if currentDesiredStatus != currentKnownStatus || evaluatingGuards {
+		if ok := s.actorStatusManager.EnqueuePendingStatus(actorKey, desiredStatus); !ok {
+			done(&actor.ActorBusyError{ActorKey: actorKey, Status: desiredStatus})
+			return
+		}
+		if evaluatingGuards {
+			s.logger.Debugf("%s is evaluating guards. Deferring update to %s.", actorKey, desiredStatus)
+		} else {
 			s.logger.Debugf("%s is already transition from %s to %s. Deferring update.", actorKey, currentKnownStatus, currentDesiredStatus)
-			if err := s.updateStatus(actorKey, desiredStatus); err != nil {
-				s.logger.Errorf("Could not update status %s for %s: %s", desiredStatus, actorKey, err)
+		}
+		done(nil)
+		return
+	}
+
+	// Is this transition valid? Wildcard actions never apply to transitions from the terminal status.
+	isValidStatus := s.actorStatusManager.IsValidTransitionStatus(actorKey, currentDesiredStatus, desiredStatus)
+	ok := isValidStatus && s.transitionManager.IsValidTransition(actorKey, currentDesiredStatus, desiredStatus)
+	if !ok && isValidStatus && s.multiHopTransitions {
+		terminalStatus := s.actorStatusManager.GetTerminalStatus(actorKey)
+		path, found := s.transitionManager.FindPath(actorKey, currentKnownStatus, desiredStatus, func(status actor.Status) bool {
+			return status != terminalStatus
+		})
+		if found {
+			s.logger.Debugf("Transitioning %s from %s to %s through %v.", actorKey, currentKnownStatus, desiredStatus, path)
+			// The remaining hops are taken once the actor has transitioned to the first status in the path.
+			s.actorStatusManager.SetRoute(actorKey, path[1:])
+			s.updateStatus(actorKey, path[0], func(err error) {
+				if err != nil {
+					s.actorStatusManager.ClearRoute(actorKey)
+				}
+				done(err)
+			})
+			return
+		}
+	}
+	if !ok {
+		done(&actor.IllegalTransitionError{ActorKey: actorKey, SrcStatus: currentDesiredStatus, DestStatus: desiredStatus})
+		return
+	}
+
+	guards := s.transitionManager.GetTransitionGuards(actorKey, currentKnownStatus, desiredStatus)
+	if len(guards) == 0 {
+		s.setDesiredStatus(actorKey, desiredStatus)
+		done(nil)
+		return
+	}
+
+	a, ok := s.actorRegistry.GetActor(actorKey)
+	if !ok {
+		done(&actor.UnknownActorError{ActorKey: actorKey})
+		return
+	}
+	// Guards are evaluated by the actor, after which the result is handed back to slashie.
+	s.logger.Debugf("Evaluating guards for %s: %s -> %s", actorKey, currentKnownStatus, desiredStatus)
+	s.evaluatingGuards[actorKey] = struct{}{}
+	err := a.TryNotify(func() {
+		for _, guard := range guards {
+			if !guard() {
+				s.mailbox <- func() {
+					delete(s.evaluatingGuards, actorKey)
+					done(&actor.TransitionRejectedError{ActorKey: actorKey, SrcStatus: currentKnownStatus, DestStatus: desiredStatus})
+					// Any updates which arrived while the guards were being evaluated can now be handled.
+					if !s.isBusy(actorKey) {
+						s.processPendingStatus(actorKey)
+					}
+				}
+				return
+			}
+		}
+		s.mailbox <- func() {
+			delete(s.evaluatingGuards, actorKey)
+			// The actor may have changed status while the guards were being evaluated, in which case we start over.
+			knownStatus := s.actorStatusManager.GetKnownStatus(actorKey)
+			if knownStatus != currentKnownStatus || s.actorStatusManager.GetDesiredStatus(actorKey) != knownStatus {
+				s.updateStatus(actorKey, desiredStatus, done)
+				return
 			}
+			s.setDesiredStatus(actorKey, desiredStatus)
+			done(nil)
 		}
-		return nil
+	})
+	if err != nil {
+		delete(s.evaluatingGuards, actorKey)
+		done(&actor.ActorStoppedError{ActorKey: actorKey})
+	}
+}
+
+// isBusy returns true if the given actor is transitioning, or its guards are being evaluated for a transition.
+func (s *slashie) isBusy(actorKey actor.Key) bool {
+	if _, ok := s.evaluatingGuards[actorKey]; ok {
+		return true
 	}
+	return s.actorStatusManager.GetDesiredStatus(actorKey) != s.actorStatusManager.GetKnownStatus(actorKey)
+}
 
-	// Is this transition valid?
-	ok := s.transitionManager.IsValidTransition(actorKey, currentDesiredStatus, desiredStatus)
+// processPendingStatus will update the status of the given actor to its next pending status, if there is one. This
+// continues until either the actor begins transitioning or there are no more pending statuses.
+func (s *slashie) processPendingStatus(actorKey actor.Key) {
+	status, ok := s.actorStatusManager.DequeuePendingStatus(actorKey)
 	if !ok {
-		return fmt.Errorf("transitioning from %s to %s is an illegal transition for actor %s", currentDesiredStatus, desiredStatus, actorKey)
+		s.applyDeferredTimedTransitions(actorKey)
+		return
 	}
+	s.logger.Debugf("Processing pending status %s for %s.", status, actorKey)
+	s.updateStatus(actorKey, status, func(err error) {
+		if err != nil {
+			s.logger.Errorf("Could not update status %s for %s: %s", status, actorKey, err)
+		}
+		if !s.isBusy(actorKey) {
+			s.processPendingStatus(actorKey)
+		}
+	})
+}
 
+// setDesiredStatus sets the desired status for the given actor and begins transitioning to it.
+func (s *slashie) setDesiredStatus(actorKey actor.Key, desiredStatus actor.Status) {
 	s.logger.Debugf("Setting %s desired status to %s", actorKey, desiredStatus)
 	s.actorStatusManager.SetDesiredStatus(actorKey, desiredStatus)
 
 	s.mailbox <- func() {
 		s.performTransition(actorKey)
 	}
-
-	return nil
 }
 
 func (s *slashie) performTransition(actorKey actor.Key) {
 	desiredStatus := s.actorStatusManager.GetDesiredStatus(actorKey)
-	// This will check to see if the current actor has any dependencies that it must wait for to transition.
-	// If so, then this will block the current actor from transitioning to its desired status.
-	hasDependencies := s.dependencyManager.HasTransitionDependencies(actorKey, desiredStatus)
-	if hasDependencies {
-		s.logger.Infof("%s has a transition dependencies to %s", actorKey, desiredStatus)
+	knownStatus := s.actorStatusManager.GetKnownStatus(actorKey)
+	if desiredStatus == knownStatus {
+		s.logger.Debugf("%s is not transitioning. Skipping transition.", actorKey)
 		return
 	}
+	// This will check to see if the current actor has any dependencies that it must wait for to transition.
+	// If so, then this will block the current actor from transitioning to its desired status. Entering a child status
+	// also enters its parents, so dependencies on the parents must be satisfied as well.
+	for _, status := range s.enteredStatuses(actorKey, knownStatus, desiredStatus) {
+		hasDependencies := s.dependencyManager.HasTransitionDependencies(actorKey, status)
+		if hasDependencies {
+			s.logger.Infof("%s has a transition dependencies to %s", actorKey, status)
+			return
+		}
+	}
 
 	a, ok := s.actorRegistry.GetActor(actorKey)
 	if !ok {
@@ -164,26 +422,64 @@ func (s *slashie) performTransition(actorKey actor.Key) {
 		return
 	}
 
-	knownStatus := s.actorStatusManager.GetKnownStatus(actorKey)
+	// Exit hooks are sent to the actor ahead of any actions, so they will complete before the transition begins.
+	// Hooks for a parent status only run when the actor is leaving the parent entirely.
+	s.handleExitHooks(a, s.enteredStatuses(actorKey, desiredStatus, knownStatus))
 	// If we get this far, then we must first execute all actions before we can transition from the knownStatus
 	// to the desiredStatus.
 	s.logger.Debugf("Starting transition for %s: %s -> %s", actorKey, knownStatus, desiredStatus)
-	s.transitionManager.StartTransition(actorKey, knownStatus, desiredStatus, func(action transition.Action) {
+	s.transitionManager.StartTransition(actorKey, knownStatus, desiredStatus, func(transitionId uint64, action transition.Action) {
 		a.Notify(actor.Message(func() {
 			err := action()
-			s.completeAction(actorKey, err)
+			s.completeAction(actorKey, transitionId, err)
 		}))
 	})
 }
 
-func (s *slashie) completeAction(actorKey actor.Key, result error) {
+// handleExitHooks sends the exit hooks for each of the given statuses to the actor, unless they have already been sent
+// since the actor's known status last changed.
+func (s *slashie) handleExitHooks(a actor.Actor, statuses []actor.Status) {
+	actorKey := a.GetKey()
+	for _, status := range statuses {
+		if _, ok := s.exitedStatuses[actorKey][status]; ok {
+			continue
+		}
+		if _, ok := s.exitedStatuses[actorKey]; !ok {
+			s.exitedStatuses[actorKey] = map[actor.Status]struct{}{}
+		}
+		s.exitedStatuses[actorKey][status] = struct{}{}
+		s.hookManager.HandleExitHooks(actorKey, status, func(h hook.Hook) {
+			a.Notify(actor.Message(h))
+		})
+	}
+}
+
+// enteredStatuses returns the statuses which an actor enters when transitioning from srcStatus to destStatus. This
+// includes destStatus and any of its parents which are not also parents of srcStatus, ordered from the innermost to
+// the outermost status.
+func (s *slashie) enteredStatuses(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status) []actor.Status {
+	srcLineage := map[actor.Status]struct{}{}
+	for _, status := range s.actorStatusManager.GetLineage(actorKey, srcStatus) {
+		srcLineage[status] = struct{}{}
+	}
+	var statuses []actor.Status
+	for _, status := range s.actorStatusManager.GetLineage(actorKey, destStatus) {
+		if _, ok := srcLineage[status]; !ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+func (s *slashie) completeAction(actorKey actor.Key, transitionId uint64, result error) {
 	s.mailbox <- func() {
-		s.transitionManager.CompleteTransitionAction(actorKey, result, func(results chan error) {
+		s.transitionManager.CompleteTransitionAction(actorKey, transitionId, result, func(results chan error) {
 			newStatus := s.actorStatusManager.GetDesiredStatus(actorKey)
 			for r := range results {
 				if r != nil {
 					s.logger.Errorf("There was an error running transition actions for actor %s: %s", actorKey, r)
 					newStatus = s.actorStatusManager.GetTerminalStatus(actorKey)
+					s.actorStatusManager.SetDesiredStatus(actorKey, newStatus)
 					break
 				}
 			}
@@ -193,35 +489,141 @@ func (s *slashie) completeAction(actorKey actor.Key, result error) {
 }
 
 func (s *slashie) updateKnownStatus(actorKey actor.Key, newStatus actor.Status) {
-	// Execute any subscriptions that are waiting for the actor to transition.
+	// Hooks, subscriptions and dependencies for a parent status are only handled when the actor first enters it.
+	// Outer statuses are handled before inner statuses.
+	knownStatus := s.actorStatusManager.GetKnownStatus(actorKey)
+	enteredStatuses := s.enteredStatuses(actorKey, knownStatus, newStatus)
+	// Timed transitions are cancelled once the actor leaves the status they were started for.
+	for _, status := range s.enteredStatuses(actorKey, newStatus, knownStatus) {
+		s.timerManager.StopTimers(actorKey, status)
+	}
 	if a, ok := s.actorRegistry.GetActor(actorKey); ok {
-		s.subscriptionManager.HandleSubscriptionsForStatus(actorKey, newStatus, func(s subscription.Subscription) {
-			//a.SendMessage(s)
-			a.Notify(actor.Message(func() {
-				s()
-			}))
-		})
+		// Exit hooks are usually sent when the transition starts, but not if the actor moves to its cancelled status
+		// before any transition has started.
+		s.handleExitHooks(a, s.enteredStatuses(actorKey, newStatus, knownStatus))
+		// Enter hooks are sent to the actor ahead of subscriptions, so that any setup for the new status has completed.
+		for i := len(enteredStatuses) - 1; i >= 0; i-- {
+			s.hookManager.HandleEnterHooks(actorKey, enteredStatuses[i], func(h hook.Hook) {
+				a.Notify(actor.Message(h))
+			})
+		}
+		// Execute any subscriptions that are waiting for the actor to transition.
+		for i := len(enteredStatuses) - 1; i >= 0; i-- {
+			s.subscriptionManager.HandleSubscriptionsForStatus(actorKey, enteredStatuses[i], func(s subscription.Subscription) {
+				//a.SendMessage(s)
+				a.Notify(actor.Message(func() {
+					s()
+				}))
+			})
+		}
 	}
 
 	s.logger.Infof("Setting known status for %s to %s.", actorKey, newStatus)
 	s.actorStatusManager.SetKnownStatus(actorKey, newStatus)
+	delete(s.exitedStatuses, actorKey)
 
 	// Notify all dependencies that the current actor transitioned to the new status. This might result in other actors
 	// transitioning to their destination status.
-	s.dependencyManager.NotifyDependenciesOfStatus(actorKey, newStatus, func(depToNotify actor.Key) {
-		s.mailbox <- func() {
-			s.logger.Debugf("Notifying %s.", depToNotify)
-			s.performTransition(depToNotify)
-		}
-	})
+	for i := len(enteredStatuses) - 1; i >= 0; i-- {
+		s.dependencyManager.NotifyDependenciesOfStatus(actorKey, enteredStatuses[i], func(depToNotify actor.Key) {
+			s.mailbox <- func() {
+				s.logger.Debugf("Notifying %s.", depToNotify)
+				s.performTransition(depToNotify)
+			}
+		})
+	}
 
 	terminalStatus := s.actorStatusManager.GetTerminalStatus(actorKey)
 	if newStatus == terminalStatus {
+		s.actorStatusManager.ClearPendingStatuses(actorKey)
+		s.actorStatusManager.ClearRoute(actorKey)
+		s.timerManager.StopAllTimers(actorKey)
+		s.schedulerManager.CancelAll(actorKey)
+		s.armScheduleTimer()
+		s.topicManager.UnsubscribeAll(actorKey)
+		s.removeFromPools(actorKey)
 		if a, ok := s.actorRegistry.GetActor(actorKey); ok {
 			s.logger.Debugf("Stopping %s", actorKey)
 			a.Stop()
 		}
+		return
+	}
+
+	for i := len(enteredStatuses) - 1; i >= 0; i-- {
+		s.startTimers(actorKey, enteredStatuses[i])
+	}
+
+	// Now that the transition has completed, the actor can continue along its route, or handle any status updates
+	// which arrived in the meantime.
+	if newStatus == s.actorStatusManager.GetDesiredStatus(actorKey) {
+		s.continueRoute(actorKey)
+	}
+}
+
+// continueRoute will transition the given actor to the next status in its route. If the route is empty, or the next
+// transition fails to start, then any pending statuses are processed instead.
+func (s *slashie) continueRoute(actorKey actor.Key) {
+	status, ok := s.actorStatusManager.NextRouteStatus(actorKey)
+	if !ok {
+		s.processPendingStatus(actorKey)
+		return
+	}
+	s.logger.Debugf("Continuing route for %s to %s.", actorKey, status)
+	s.updateStatus(actorKey, status, func(err error) {
+		if err != nil {
+			s.logger.Errorf("Could not update status %s for %s: %s", status, actorKey, err)
+			s.actorStatusManager.ClearRoute(actorKey)
+		}
+		if !s.isBusy(actorKey) {
+			s.processPendingStatus(actorKey)
+		}
+	})
+}
+
+// startTimers starts the timed transitions for the given actor & status. When a timer fires, the actor is moved to
+// the timed transition's destination status, as long as it has not left the status or begun another transition.
+func (s *slashie) startTimers(actorKey actor.Key, status actor.Status) {
+	s.timerManager.StartTimers(actorKey, status, func(destStatus actor.Status, generation uint64) {
+		s.mailbox <- func() {
+			if !s.timerManager.IsActive(actorKey, status, generation) {
+				return
+			}
+			if s.isBusy(actorKey) {
+				// The timed transition applies once the transition completes, if the actor is still in the status.
+				knownStatus := s.actorStatusManager.GetKnownStatus(actorKey)
+				s.logger.Debugf("%s is transitioning from %s. Deferring timed transition to %s.", actorKey, knownStatus, destStatus)
+				s.timerManager.DeferTimedTransition(actorKey, status, generation, destStatus)
+				return
+			}
+			s.logger.Infof("%s has been in %s for too long. Transitioning to %s.", actorKey, status, destStatus)
+			s.updateStatus(actorKey, destStatus, func(err error) {
+				if err != nil {
+					s.logger.Errorf("Could not update status %s for %s: %s", destStatus, actorKey, err)
+				}
+			})
+		}
+	})
+}
+
+// applyDeferredTimedTransitions moves the given actor to the destination status of the first timed transition which
+// fired while it was transitioning, and which still applies. It must only be called once the actor's transition has
+// completed.
+func (s *slashie) applyDeferredTimedTransitions(actorKey actor.Key) {
+	s.applyTimedTransitions(actorKey, s.timerManager.TakeDeferredTransitions(actorKey))
+}
+
+func (s *slashie) applyTimedTransitions(actorKey actor.Key, deferred []timer.DeferredTransition) {
+	if len(deferred) == 0 {
+		return
 	}
+	next := deferred[0]
+	s.logger.Infof("%s has been in %s for too long. Transitioning to %s.", actorKey, next.Status, next.DestStatus)
+	s.updateStatus(actorKey, next.DestStatus, func(err error) {
+		if err != nil {
+			s.logger.Errorf("Could not update status %s for %s: %s", next.DestStatus, actorKey, err)
+			s.applyTimedTransitions(actorKey, deferred[1:])
+		}
+	})
 }
 
 func (s *slashie) AddTransitionDependency(srcActor actor.Actor, srcStatus actor.Status, depActor actor.Actor, depStatus actor.Status) error {
@@ -231,13 +633,13 @@ func (s *slashie) AddTransitionDependency(srcActor actor.Actor, srcStatus actor.
 
 		srcKey := srcActor.GetKey()
 		if ok := s.actorRegistry.IsRegistered(srcActor); !ok {
-			errChan <- fmt.Errorf("unknown actor %s", srcKey)
+			errChan <- &actor.UnknownActorError{ActorKey: srcKey}
 			return
 		}
 
 		depKey := depActor.GetKey()
 		if ok := s.actorRegistry.IsRegistered(depActor); !ok {
-			errChan <- fmt.Errorf("unknown actor %s", depKey)
+			errChan <- &actor.UnknownActorError{ActorKey: depKey}
 			return
 		}
 
@@ -246,9 +648,72 @@ func (s *slashie) AddTransitionDependency(srcActor actor.Actor, srcStatus actor.
 	return <-errChan
 }
 
+func (s *slashie) RemoveTransitionDependency(srcActor actor.Actor, srcStatus actor.Status, depActor actor.Actor, depStatus actor.Status) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+
+		srcKey := srcActor.GetKey()
+		if ok := s.actorRegistry.IsRegistered(srcActor); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: srcKey}
+			return
+		}
+
+		depKey := depActor.GetKey()
+		if ok := s.actorRegistry.IsRegistered(depActor); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: depKey}
+			return
+		}
+
+		errChan <- s.dependencyManager.RemoveTransitionDependency(srcKey, srcStatus, depKey, depStatus, func(actorKey actor.Key) {
+			// Only start transitioning if the actor is waiting to enter srcStatus, either directly or through one of
+			// its children.
+			if _, ok := s.transitionManager.GetInFlightTransition(actorKey); ok {
+				return
+			}
+			knownStatus := s.actorStatusManager.GetKnownStatus(actorKey)
+			desiredStatus := s.actorStatusManager.GetDesiredStatus(actorKey)
+			for _, status := range s.enteredStatuses(actorKey, knownStatus, desiredStatus) {
+				if status == srcStatus {
+					s.logger.Debugf("Removed last dependency for %s. Starting transition to %s.", actorKey, desiredStatus)
+					s.performTransition(actorKey)
+					return
+				}
+			}
+		})
+	}
+	return <-errChan
+}
+
+func (s *slashie) GetTransitionDependencies(a actor.Actor, status actor.Status) map[actor.Key]actor.Status {
+	responseChan := make(chan map[actor.Key]actor.Status)
+	s.mailbox <- func() {
+		defer close(responseChan)
+
+		responseChan <- s.dependencyManager.GetTransitionDependencies(a.GetKey(), status)
+	}
+	return <-responseChan
+}
+
+func (s *slashie) GetDependents(a actor.Actor, status actor.Status) map[actor.Key]actor.Status {
+	responseChan := make(chan map[actor.Key]actor.Status)
+	s.mailbox <- func() {
+		defer close(responseChan)
+
+		responseChan <- s.dependencyManager.GetDependents(a.GetKey(), status)
+	}
+	return <-responseChan
+}
+
 func (s *slashie) AddTransitionActions(actor actor.Actor, actions []*transition.TransitionAction) error {
 	for _, action := range actions {
-		if err := s.AddTransitionAction(actor, action.SrcStatus, action.DestStatus, action.Action); err != nil {
+		var err error
+		if action.SrcStatus == transition.AnyStatus {
+			err = s.AddWildcardTransitionAction(actor, action.DestStatus, action.Action, action.ExcludedStatuses...)
+		} else {
+			err = s.AddTransitionAction(actor, action.SrcStatus, action.DestStatus, action.Action)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -262,7 +727,7 @@ func (s *slashie) AddTransitionAction(a actor.Actor, srcStatus actor.Status, des
 		actorKey := a.GetKey()
 
 		if isValid := s.actorStatusManager.IsValidTransitionStatus(actorKey, srcStatus, destStatus); !isValid {
-			errChan <- fmt.Errorf("cannot transition from %s to %s", srcStatus, destStatus)
+			errChan <- &actor.IllegalTransitionError{ActorKey: actorKey, SrcStatus: srcStatus, DestStatus: destStatus}
 			return
 		}
 		s.logger.Debugf("Adding transaction action for %s for %s -> %s.", actorKey, srcStatus, destStatus)
@@ -271,6 +736,183 @@ func (s *slashie) AddTransitionAction(a actor.Actor, srcStatus actor.Status, des
 	return <-errChan
 }
 
+func (s *slashie) AddTransitionContextAction(a actor.Actor, srcStatus actor.Status, destStatus actor.Status, action transition.ContextAction) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+		actorKey := a.GetKey()
+
+		if isValid := s.actorStatusManager.IsValidTransitionStatus(actorKey, srcStatus, destStatus); !isValid {
+			errChan <- &actor.IllegalTransitionError{ActorKey: actorKey, SrcStatus: srcStatus, DestStatus: destStatus}
+			return
+		}
+		s.logger.Debugf("Adding transaction action for %s for %s -> %s.", actorKey, srcStatus, destStatus)
+		s.transitionManager.AddTransitionContextAction(actorKey, srcStatus, destStatus, action)
+	}
+	return <-errChan
+}
+
+func (s *slashie) AddTransitionActorAction(a actor.Actor, srcStatus actor.Status, destStatus actor.Status, action ActorAction) error {
+	return s.AddTransitionContextAction(a, srcStatus, destStatus, func(ctx context.Context) error {
+		return action(&actorContext{ctx: ctx, self: a, s: s})
+	})
+}
+
+func (s *slashie) AddWildcardTransitionAction(a actor.Actor, destStatus actor.Status, action transition.Action, excludedStatuses ...actor.Status) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+		actorKey := a.GetKey()
+
+		if isValid := s.actorStatusManager.IsValidTransitionStatus(actorKey, transition.AnyStatus, destStatus); !isValid {
+			errChan <- &actor.IllegalTransitionError{ActorKey: actorKey, SrcStatus: transition.AnyStatus, DestStatus: destStatus}
+			return
+		}
+		s.logger.Debugf("Adding wildcard transition action for %s for * -> %s excluding %v.", actorKey, destStatus, excludedStatuses)
+		s.transitionManager.AddWildcardTransitionAction(actorKey, excludedStatuses, destStatus, action)
+	}
+	return <-errChan
+}
+
+func (s *slashie) AddTransitionGuard(a actor.Actor, srcStatus actor.Status, destStatus actor.Status, guard transition.Guard) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+		actorKey := a.GetKey()
+
+		if isValid := s.actorStatusManager.IsValidTransitionStatus(actorKey, srcStatus, destStatus); !isValid {
+			errChan <- &actor.IllegalTransitionError{ActorKey: actorKey, SrcStatus: srcStatus, DestStatus: destStatus}
+			return
+		}
+		s.logger.Debugf("Adding transition guard for %s for %s -> %s.", actorKey, srcStatus, destStatus)
+		s.transitionManager.AddTransitionGuard(actorKey, srcStatus, destStatus, guard)
+	}
+	return <-errChan
+}
+
+func (s *slashie) SetCancelledStatus(a actor.Actor, status actor.Status) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+
+		actorKey := a.GetKey()
+		if ok := s.actorRegistry.IsRegistered(a); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: actorKey}
+			return
+		}
+		// An actor cannot transition back to its initial status.
+		if status == s.actorStatusManager.GetInitialStatus(actorKey) {
+			errChan <- &actor.IllegalTransitionError{ActorKey: actorKey, SrcStatus: transition.AnyStatus, DestStatus: status}
+			return
+		}
+
+		s.actorStatusManager.SetCancelledStatus(actorKey, status)
+	}
+	return <-errChan
+}
+
+func (s *slashie) AddChildStatus(a actor.Actor, parentStatus actor.Status, childStatus actor.Status) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+
+		actorKey := a.GetKey()
+		if ok := s.actorRegistry.IsRegistered(a); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: actorKey}
+			return
+		}
+		// A status can only have one parent, and cannot be nested under itself.
+		_, hasParent := s.actorStatusManager.GetParentStatus(actorKey, childStatus)
+		if hasParent || s.actorStatusManager.IsInLineage(actorKey, parentStatus, childStatus) {
+			errChan <- &actor.InvalidStatusHierarchyError{ActorKey: actorKey, ParentStatus: parentStatus, ChildStatus: childStatus}
+			return
+		}
+
+		s.logger.Debugf("Adding child status %s to %s for %s.", childStatus, parentStatus, actorKey)
+		s.actorStatusManager.AddChildStatus(actorKey, parentStatus, childStatus)
+	}
+	return <-errChan
+}
+
+func (s *slashie) AddTimedTransition(a actor.Actor, status actor.Status, after time.Duration, destStatus actor.Status) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+
+		actorKey := a.GetKey()
+		if ok := s.actorRegistry.IsRegistered(a); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: actorKey}
+			return
+		}
+		if isValid := s.isReachable(actorKey, status, destStatus); !isValid {
+			errChan <- &actor.IllegalTransitionError{ActorKey: actorKey, SrcStatus: status, DestStatus: destStatus}
+			return
+		}
+
+		s.logger.Debugf("Adding timed transition for %s for %s -> %s after %s.", actorKey, status, destStatus, after)
+		s.timerManager.AddTimedTransition(actorKey, status, after, destStatus)
+		// If the actor is already in the given status, then the timer starts now.
+		if s.isInStatus(actorKey, status) {
+			s.startTimers(actorKey, status)
+		}
+	}
+	return <-errChan
+}
+
+// isReachable returns true if the given actor can transition from the srcStatus to the destStatus, either directly
+// or, if multi-hop transitions are enabled, through other statuses.
+func (s *slashie) isReachable(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status) bool {
+	if !s.actorStatusManager.IsValidTransitionStatus(actorKey, srcStatus, destStatus) {
+		return false
+	}
+	if s.transitionManager.IsValidTransition(actorKey, srcStatus, destStatus) {
+		return true
+	}
+	if !s.multiHopTransitions {
+		return false
+	}
+	terminalStatus := s.actorStatusManager.GetTerminalStatus(actorKey)
+	_, found := s.transitionManager.FindPath(actorKey, srcStatus, destStatus, func(status actor.Status) bool {
+		return status != terminalStatus
+	})
+	return found
+}
+
+func (s *slashie) CancelTransition(a actor.Actor, reason string) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+
+		actorKey := a.GetKey()
+		if ok := s.actorRegistry.IsRegistered(a); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: actorKey}
+			return
+		}
+
+		knownStatus := s.actorStatusManager.GetKnownStatus(actorKey)
+		desiredStatus := s.actorStatusManager.GetDesiredStatus(actorKey)
+		if knownStatus == desiredStatus {
+			errChan <- &actor.NotTransitioningError{ActorKey: actorKey, Status: knownStatus}
+			return
+		}
+
+		s.logger.Infof("Cancelling transition for %s from %s to %s: %s", actorKey, knownStatus, desiredStatus, reason)
+		s.actorStatusManager.ClearRoute(actorKey)
+		// The actor may still be waiting on dependencies, in which case no actions have been started.
+		s.transitionManager.CancelTransition(actorKey, reason)
+
+		cancelledStatus, ok := s.actorStatusManager.GetCancelledStatus(actorKey)
+		if !ok || cancelledStatus == knownStatus {
+			s.actorStatusManager.SetDesiredStatus(actorKey, knownStatus)
+			s.processPendingStatus(actorKey)
+			return
+		}
+		s.actorStatusManager.SetDesiredStatus(actorKey, cancelledStatus)
+		s.updateKnownStatus(actorKey, cancelledStatus)
+	}
+	return <-errChan
+}
+
 func (s *slashie) GetStatus(a actor.Actor) actor.Status {
 	responseChan := make(chan actor.Status)
 	s.mailbox <- func() {
@@ -282,6 +924,88 @@ func (s *slashie) GetStatus(a actor.Actor) actor.Status {
 	return <-responseChan
 }
 
+func (s *slashie) FindActors(query ActorQuery) ([]actor.Actor, error) {
+	type result struct {
+		actors []actor.Actor
+		err    error
+	}
+	resultChan := make(chan result)
+	s.mailbox <- func() {
+		defer close(resultChan)
+
+		candidates := s.actorRegistry.ListActors()
+		if query.KeyPattern != "" {
+			var err error
+			if candidates, err = s.actorRegistry.ListByKeyPattern(query.KeyPattern); err != nil {
+				resultChan <- result{err: err}
+				return
+			}
+		}
+
+		var actors []actor.Actor
+		for _, a := range candidates {
+			if query.Type != "" && a.GetType() != query.Type {
+				continue
+			}
+			if query.Status != "" && !s.isInStatus(a.GetKey(), query.Status) {
+				continue
+			}
+			actors = append(actors, a)
+		}
+		resultChan <- result{actors: actors}
+	}
+	r := <-resultChan
+	return r.actors, r.err
+}
+
+func (s *slashie) Snapshot() Snapshot {
+	snapshotChan := make(chan Snapshot)
+	s.mailbox <- func() {
+		defer close(snapshotChan)
+
+		snapshot := Snapshot{
+			Timestamp:       s.clock.Now(),
+			MailboxLen:      len(s.mailbox),
+			DeadLetterCount: s.deadLetterManager.Count(),
+		}
+		for _, a := range s.actorRegistry.ListActors() {
+			snapshot.Actors = append(snapshot.Actors, s.actorSnapshot(a))
+		}
+		snapshotChan <- snapshot
+	}
+	return <-snapshotChan
+}
+
+// actorSnapshot returns a point-in-time view of the given actor.
+func (s *slashie) actorSnapshot(a actor.Actor) ActorSnapshot {
+	actorKey := a.GetKey()
+	snapshot := ActorSnapshot{
+		Key:            actorKey,
+		Type:           a.GetType(),
+		Id:             a.GetId(),
+		InitialStatus:  s.actorStatusManager.GetInitialStatus(actorKey),
+		TerminalStatus: s.actorStatusManager.GetTerminalStatus(actorKey),
+		KnownStatus:    s.actorStatusManager.GetKnownStatus(actorKey),
+		DesiredStatus:  s.actorStatusManager.GetDesiredStatus(actorKey),
+		Dependencies:   s.dependencyManager.GetAllTransitionDependencies(actorKey),
+		Subscriptions:  s.subscriptionManager.GetSubscriptionCounts(actorKey),
+		MailboxLen:     actor.MailboxLen(a),
+	}
+	if t, ok := s.transitionManager.GetInFlightTransition(actorKey); ok {
+		snapshot.Transition = &TransitionSnapshot{
+			SrcStatus:          t.SrcStatus,
+			DestStatus:         t.DestStatus,
+			OutstandingActions: t.OutstandingActions,
+		}
+	}
+	return snapshot
+}
+
+// isInStatus returns true if the known status of the given actor is the given status, or is nested under it.
+func (s *slashie) isInStatus(actorKey actor.Key, status actor.Status) bool {
+	return s.actorStatusManager.IsInLineage(actorKey, s.actorStatusManager.GetKnownStatus(actorKey), status)
+}
+
 func (s *slashie) Subscribe(a actor.Actor, status actor.Status, callback subscription.Subscription) error {
 	errChan := make(chan error)
 	s.mailbox <- func() {
@@ -290,7 +1014,7 @@ func (s *slashie) Subscribe(a actor.Actor, status actor.Status, callback subscri
 		actorKey := a.GetKey()
 		isValid := s.actorStatusManager.IsValidSubscriptionStatus(actorKey, status)
 		if !isValid {
-			errChan <- fmt.Errorf("cannot subscribe to current status %s", status)
+			errChan <- &actor.InvalidSubscriptionStatusError{ActorKey: actorKey, Status: status}
 			return
 		}
 
@@ -299,20 +1023,367 @@ func (s *slashie) Subscribe(a actor.Actor, status actor.Status, callback subscri
 	return <-errChan
 }
 
-func (s *slashie) SendMessage(actorKey actor.Key, message any) error {
+func (s *slashie) SubscribeTopic(a actor.Actor, pattern string) error {
 	errChan := make(chan error)
 	s.mailbox <- func() {
 		defer close(errChan)
 
-		actor, ok := s.actorRegistry.GetActor(actorKey)
-		if !ok {
-			errChan <- fmt.Errorf("could not send message to %s: unknown actor", actorKey)
+		actorKey := a.GetKey()
+		if ok := s.actorRegistry.IsRegistered(a); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: actorKey}
 			return
 		}
-		if err := actor.SendMessage(message); err != nil {
-			errChan <- fmt.Errorf("could not send message to %s: %s", actorKey, err)
+		if err := s.topicManager.Subscribe(actorKey, pattern); err != nil {
+			errChan <- err
 			return
 		}
+		s.logger.Debugf("Subscribed %s to %s.", actorKey, pattern)
 	}
 	return <-errChan
 }
+
+func (s *slashie) UnsubscribeTopic(a actor.Actor, pattern string) bool {
+	resultChan := make(chan bool)
+	s.mailbox <- func() {
+		defer close(resultChan)
+
+		resultChan <- s.topicManager.Unsubscribe(a.GetKey(), pattern)
+	}
+	return <-resultChan
+}
+
+func (s *slashie) Publish(topicName string, message any) error {
+	type subscribers struct {
+		actors []actor.Actor
+		err    error
+	}
+	resultChan := make(chan subscribers)
+	s.mailbox <- func() {
+		defer close(resultChan)
+
+		actorKeys, err := s.topicManager.Subscribers(topicName)
+		if err != nil {
+			resultChan <- subscribers{err: err}
+			return
+		}
+		var actors []actor.Actor
+		for _, actorKey := range actorKeys {
+			if a, ok := s.actorRegistry.GetActor(actorKey); ok {
+				actors = append(actors, a)
+			}
+		}
+		resultChan <- subscribers{actors: actors}
+	}
+	result := <-resultChan
+	if result.err != nil {
+		return result.err
+	}
+
+	// Each subscriber receives the same Envelope, so that the message has a single Id. Messages are delivered outside
+	// the coordinator, since an actor may interact with slashie while handling them. Delivery never holds up the
+	// publisher, which may itself be a subscriber, or the other subscribers.
+	envelope := actor.Seal(message).WithHeader(TopicHeader, topicName)
+	for _, a := range result.actors {
+		actorKey := a.GetKey()
+		s.deliveries.push(delivery{actor: a, message: envelope, done: func(err error) {
+			switch {
+			case err == nil:
+			case errors.Is(err, actor.ErrActorStopped):
+				s.mailbox <- func() {
+					s.logger.Debugf("%s has stopped. Removing topic subscriptions.", actorKey)
+					s.topicManager.UnsubscribeAll(actorKey)
+				}
+			case errors.Is(err, actor.ErrMailboxFull):
+				// Messages rejected by the subscriber's mailbox are not passed to its dead letter handler.
+				s.logger.Warnf("Could not publish message on %s to %s: %s", topicName, actorKey, err)
+				s.deadLetterManager.Publish(actor.DeadLetter{
+					ActorKey:  actorKey,
+					Message:   envelope.Message,
+					Reason:    err,
+					Timestamp: s.clock.Now(),
+				})
+			default:
+				s.logger.Warnf("Could not publish message on %s to %s: %s", topicName, actorKey, err)
+			}
+		}})
+	}
+	return nil
+}
+
+func (s *slashie) OnEnter(a actor.Actor, status actor.Status, h hook.Hook) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+
+		actorKey := a.GetKey()
+		if ok := s.actorRegistry.IsRegistered(a); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: actorKey}
+			return
+		}
+
+		s.hookManager.AddEnterHook(actorKey, status, h)
+	}
+	return <-errChan
+}
+
+func (s *slashie) OnExit(a actor.Actor, status actor.Status, h hook.Hook) error {
+	errChan := make(chan error)
+	s.mailbox <- func() {
+		defer close(errChan)
+
+		actorKey := a.GetKey()
+		if ok := s.actorRegistry.IsRegistered(a); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: actorKey}
+			return
+		}
+
+		s.hookManager.AddExitHook(actorKey, status, h)
+	}
+	return <-errChan
+}
+
+func (s *slashie) SendMessage(actorKey actor.Key, message any) error {
+	lookup := s.getActor(actorKey)
+	s.mailbox <- lookup.message
+	a, ok := <-lookup.result
+	if !ok {
+		return s.unknownActor(actorKey, message)
+	}
+	// The message is sent outside the coordinator, since the actor may be blocked on slashie while handling a
+	// previous message.
+	if err := a.SendMessage(message); err != nil {
+		return fmt.Errorf("could not send message to %s: %w", actorKey, err)
+	}
+	return nil
+}
+
+func (s *slashie) Ask(ctx context.Context, actorKey actor.Key, message any) (any, error) {
+	lookup := s.getActor(actorKey)
+	s.mailbox <- lookup.message
+	a, ok := <-lookup.result
+	if !ok {
+		return nil, s.unknownActor(actorKey, message)
+	}
+	// The reply is awaited outside the coordinator, since the actor may interact with slashie while handling the
+	// message.
+	reply, err := a.Ask(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf("could not ask %s: %w", actorKey, err)
+	}
+	return reply, nil
+}
+
+func (s *slashie) TrySendMessage(actorKey actor.Key, message any) error {
+	return s.trySendMessage(actorKey, time.Time{}, message)
+}
+
+func (s *slashie) TrySendMessageUntil(actorKey actor.Key, deadline time.Time, message any) error {
+	return s.trySendMessage(actorKey, deadline, message)
+}
+
+// trySendMessage sends a message to the given actor, returning a MailboxFullError if the actor's mailbox does not have
+// room for it before the deadline. A zero deadline returns immediately.
+func (s *slashie) trySendMessage(actorKey actor.Key, deadline time.Time, message any) error {
+	a, err := s.tryGetActor(actorKey, deadline)
+	if err != nil {
+		return err
+	}
+	if a == nil {
+		return s.unknownActor(actorKey, message)
+	}
+
+	if deadline.IsZero() {
+		err = a.TrySendMessage(message)
+	} else {
+		err = a.TrySendMessageUntil(deadline, message)
+	}
+	if err != nil {
+		return fmt.Errorf("could not send message to %s: %w", actorKey, err)
+	}
+	return nil
+}
+
+// unknownActor publishes a dead letter for a message sent to an actor which does not exist, and returns the
+// UnknownActorError.
+func (s *slashie) unknownActor(actorKey actor.Key, message any) error {
+	err := &actor.UnknownActorError{ActorKey: actorKey}
+	s.deadLetterManager.Publish(actor.DeadLetter{
+		ActorKey:  actorKey,
+		Message:   actor.EnvelopeOf(message).Message,
+		Reason:    err,
+		Timestamp: s.clock.Now(),
+	})
+	return err
+}
+
+// publishDeadLetter passes a dead letter from an actor to the dead letter handlers. Dead letters are timestamped by
+// slashie's clock, regardless of the clock used by the actor.
+func (s *slashie) publishDeadLetter(deadLetter actor.DeadLetter) {
+	deadLetter.Timestamp = s.clock.Now()
+	s.deadLetterManager.Publish(deadLetter)
+}
+
+func (s *slashie) SubscribeDeadLetters(handler actor.DeadLetterHandler) func() {
+	return s.deadLetterManager.Subscribe(handler)
+}
+
+func (s *slashie) DeadLetterCount() uint64 {
+	return s.deadLetterManager.Count()
+}
+
+// actorLookup is a message which passes the actor for a given key to the result channel, which is closed without a
+// value if the actor does not exist.
+type actorLookup struct {
+	message message
+	result  chan actor.Actor
+}
+
+// getActor returns an actorLookup for the given actor. Actors are looked up by the slashie coordinator, so that the
+// actor can be used by the caller without holding up other messages.
+func (s *slashie) getActor(actorKey actor.Key) actorLookup {
+	result := make(chan actor.Actor, 1)
+	return actorLookup{
+		message: func() {
+			defer close(result)
+
+			if a, ok := s.actorRegistry.GetActor(actorKey); ok {
+				result <- a
+			}
+		},
+		result: result,
+	}
+}
+
+// tryGetActor returns the given actor, or nil if it does not exist. With a zero deadline, the actor is found without
+// waiting on the coordinator. Otherwise a CoordinatorBusyError is returned if the coordinator does not look up the
+// actor before the deadline.
+func (s *slashie) tryGetActor(actorKey actor.Key, deadline time.Time) (actor.Actor, error) {
+	if deadline.IsZero() {
+		if a, ok := s.addedActors.Load(actorKey); ok {
+			return a.(actor.Actor), nil
+		}
+		return nil, nil
+	}
+
+	lookup := s.getActor(actorKey)
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case s.mailbox <- lookup.message:
+	case <-timer.C:
+		return nil, &actor.CoordinatorBusyError{ActorKey: actorKey}
+	}
+	select {
+	case a := <-lookup.result:
+		return a, nil
+	case <-timer.C:
+		return nil, &actor.CoordinatorBusyError{ActorKey: actorKey}
+	}
+}
+
+func (s *slashie) SendMessageAfter(actorKey actor.Key, delay time.Duration, message any) (scheduler.Handle, error) {
+	return s.scheduleMessage(actorKey, delay, 0, message)
+}
+
+func (s *slashie) SendMessageAt(actorKey actor.Key, at time.Time, message any) (scheduler.Handle, error) {
+	return s.scheduleMessage(actorKey, at.Sub(s.clock.Now()), 0, message)
+}
+
+func (s *slashie) SendMessageEvery(actorKey actor.Key, interval time.Duration, message any) (scheduler.Handle, error) {
+	if interval <= 0 {
+		return nil, &scheduler.InvalidIntervalError{Interval: interval}
+	}
+	return s.scheduleMessage(actorKey, interval, interval, message)
+}
+
+// scheduleMessage schedules the message to be delivered to the given actor after the delay, and then every interval
+// if the interval is non-zero.
+func (s *slashie) scheduleMessage(actorKey actor.Key, delay time.Duration, interval time.Duration, message any) (scheduler.Handle, error) {
+	errChan := make(chan error, 1)
+	var handle scheduler.Handle
+	s.mailbox <- func() {
+		defer close(errChan)
+
+		if _, ok := s.actorRegistry.GetActor(actorKey); !ok {
+			errChan <- &actor.UnknownActorError{ActorKey: actorKey}
+			return
+		}
+		if s.actorStatusManager.GetKnownStatus(actorKey) == s.actorStatusManager.GetTerminalStatus(actorKey) {
+			errChan <- &actor.ActorStoppedError{ActorKey: actorKey}
+			return
+		}
+
+		id := s.schedulerManager.Schedule(actorKey, s.clock.Now().Add(delay), interval, message)
+		s.logger.Debugf("Scheduled message %d for %s after %s.", id, actorKey, delay)
+		s.armScheduleTimer()
+		handle = &scheduledMessageHandle{s: s, id: id}
+	}
+	if err := <-errChan; err != nil {
+		return nil, err
+	}
+	return handle, nil
+}
+
+// armScheduleTimer ensures that a single timer is running for the next scheduled message, if there is one.
+func (s *slashie) armScheduleTimer() {
+	next, ok := s.schedulerManager.Next()
+	if ok && s.scheduleTimer != nil && next.Equal(s.scheduledAt) {
+		return
+	}
+	if s.scheduleTimer != nil {
+		s.scheduleTimer.Stop()
+		s.scheduleTimer = nil
+	}
+	if !ok {
+		return
+	}
+	s.scheduledAt = next
+	s.scheduleTimer = s.clock.AfterFunc(next.Sub(s.clock.Now()), func() {
+		s.mailbox <- s.handleScheduledMessages
+	})
+}
+
+// handleScheduledMessages delivers all messages which are due, and then waits for the next scheduled message.
+func (s *slashie) handleScheduledMessages() {
+	s.schedulerManager.HandleDueMessages(s.clock.Now(), func(actorKey actor.Key, message any) {
+		a, ok := s.actorRegistry.GetActor(actorKey)
+		if !ok {
+			s.logger.Warnf("could not deliver scheduled message: %s", s.unknownActor(actorKey, message))
+			return
+		}
+		// Messages are delivered outside the coordinator, since an actor may interact with slashie while handling them.
+		// Each actor receives its messages in the order they became due, and an actor which is slow to accept them
+		// does not hold up delivery to the others.
+		s.deliveries.push(delivery{actor: a, message: message, done: func(err error) {
+			if errors.Is(err, actor.ErrActorStopped) {
+				s.mailbox <- func() {
+					s.logger.Debugf("%s has stopped. Cancelling scheduled messages.", actorKey)
+					s.schedulerManager.CancelAll(actorKey)
+					s.armScheduleTimer()
+				}
+			} else if err != nil {
+				s.logger.Errorf("Could not deliver scheduled message to %s: %s", actorKey, err)
+			}
+		}})
+	})
+	s.scheduleTimer = nil
+	s.armScheduleTimer()
+}
+
+// scheduledMessageHandle cancels a scheduled message through the slashie coordinator.
+type scheduledMessageHandle struct {
+	s  *slashie
+	id scheduler.Id
+}
+
+func (h *scheduledMessageHandle) Cancel() bool {
+	resultChan := make(chan bool)
+	h.s.mailbox <- func() {
+		defer close(resultChan)
+
+		ok := h.s.schedulerManager.Cancel(h.id)
+		h.s.armScheduleTimer()
+		resultChan <- ok
+	}
+	return <-resultChan
+}
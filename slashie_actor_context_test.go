@@ -0,0 +1,238 @@
+package slashie
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle_ActorContext(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	contexts := make(chan ActorContext, 1)
+	Handle(tm, basicActor, func(ctx ActorContext, message testMessage) {
+		contexts <- ctx
+	})
+
+	err := tm.SendMessage(basicActor.GetKey(), actor.Envelope{Sender: "Actor:Sender", Message: testMessage{message: "TestMessage"}})
+	assert.NoError(t, err)
+
+	ctx := <-contexts
+	assert.Equal(t, basicActor, ctx.Self())
+	assert.Equal(t, actor.Key("Actor:Sender"), ctx.Sender())
+	assert.Equal(t, tm, ctx.Coordinator())
+	assert.NotNil(t, ctx.Context())
+	assert.Equal(t, basicActor.GetLogger(), ctx.Logger())
+}
+
+// wrappedSlashie is a Slashie implemented outside of this package.
+type wrappedSlashie struct {
+	Slashie
+}
+
+func TestHandle_OtherSlashie(t *testing.T) {
+	tm := &wrappedSlashie{Slashie: NewSlashie()}
+	srcActor := NewBasicActor("Actor", "Source", tm)
+	destActor, envelopes := newTopicActor(tm, "Destination")
+
+	Handle(tm, srcActor, func(ctx ActorContext, message string) {
+		assert.Equal(t, tm, ctx.Coordinator())
+		assert.NoError(t, ctx.Send(destActor.GetKey(), testMessage{message: message}))
+	})
+	err := tm.SendMessage(srcActor.GetKey(), "TestMessage")
+	assert.NoError(t, err)
+
+	envelope := <-envelopes
+	assert.Equal(t, testMessage{message: "TestMessage"}, envelope.Message)
+}
+
+func TestActorContext_Send(t *testing.T) {
+	tm := NewSlashie()
+	srcActor := NewBasicActor("Actor", "Source", tm)
+	destActor := NewBasicActor("Actor", "Destination", tm)
+
+	envelopes := make(chan actor.Envelope, 1)
+	Handle(tm, destActor, func(ctx ActorContext, message string) {
+		envelopes <- ctx.Envelope()
+	})
+	Handle(tm, srcActor, func(ctx ActorContext, message testMessage) {
+		assert.NoError(t, ctx.Send(destActor.GetKey(), message.message))
+	})
+
+	sent := actor.Seal(testMessage{message: "FollowUp"}).WithHeader("Key", "Value")
+	err := tm.SendMessage(srcActor.GetKey(), sent)
+	assert.NoError(t, err)
+
+	// The follow-up message is part of the same conversation as the message which caused it.
+	envelope := <-envelopes
+	assert.Equal(t, "FollowUp", envelope.Message)
+	assert.Equal(t, srcActor.GetKey(), envelope.Sender)
+	assert.Equal(t, sent.CorrelationId, envelope.CorrelationId)
+	assert.Equal(t, sent.Id, envelope.CausationId)
+	assert.Equal(t, "Value", envelope.Header("Key"))
+}
+
+func TestActorContext_SendWhileBeingSentTo(t *testing.T) {
+	tm := NewSlashie()
+	srcActor := NewBasicActor("Actor", "Source", tm)
+	destActor := NewBasicActor("Actor", "Destination", tm)
+
+	received := make(chan string, 2)
+	Handle(tm, destActor, func(ctx ActorContext, message string) {
+		received <- message
+	})
+	started := make(chan bool, 2)
+	proceed := make(chan bool)
+	Handle(tm, srcActor, func(ctx ActorContext, message testMessage) {
+		started <- true
+		<-proceed
+		assert.NoError(t, ctx.Send(destActor.GetKey(), message.message))
+	})
+
+	assert.NoError(t, tm.SendMessage(srcActor.GetKey(), testMessage{message: "First"}))
+	<-started
+
+	// A message is sent to the source actor while it is sending a message of its own.
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- tm.SendMessage(srcActor.GetKey(), testMessage{message: "Second"})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(proceed)
+
+	assert.Equal(t, "First", <-received)
+	assert.NoError(t, <-errChan)
+	assert.Equal(t, "Second", <-received)
+}
+
+func TestActorContext_Ask(t *testing.T) {
+	tm := NewSlashie()
+	srcActor := NewBasicActor("Actor", "Source", tm)
+	destActor := NewBasicActor("Actor", "Destination", tm)
+
+	HandleReply(tm, destActor, func(ctx ActorContext, message string) (actor.Key, error) {
+		return ctx.Sender(), nil
+	})
+	HandleReply(tm, srcActor, func(ctx ActorContext, message testMessage) (any, error) {
+		return ctx.Ask(destActor.GetKey(), message.message)
+	})
+
+	reply, err := tm.Ask(context.Background(), srcActor.GetKey(), testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+	assert.Equal(t, srcActor.GetKey(), reply)
+}
+
+func TestHandleReply_ActorContext(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	HandleReply(tm, basicActor, func(ctx ActorContext, message testMessage) (actor.Key, error) {
+		return ctx.Self().GetKey(), nil
+	})
+
+	reply, err := tm.Ask(context.Background(), basicActor.GetKey(), testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+	assert.Equal(t, basicActor.GetKey(), reply)
+}
+
+func TestActorContext_UpdateOwnStatus(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddTransitionAction(basicActor, NoneStatus, ReadyStatus, func() error { return nil })
+	assert.NoError(t, err)
+	// Guards are evaluated on the actor's goroutine, so the update must not block the handler.
+	err = tm.AddTransitionGuard(basicActor, NoneStatus, ReadyStatus, func() bool { return true })
+	assert.NoError(t, err)
+	ready := make(chan bool)
+	err = tm.Subscribe(basicActor, ReadyStatus, func() {
+		ready <- true
+	})
+	assert.NoError(t, err)
+
+	Handle(tm, basicActor, func(ctx ActorContext, message testMessage) {
+		ctx.UpdateOwnStatus(ReadyStatus)
+	})
+	err = tm.SendMessage(basicActor.GetKey(), testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+
+	assert.True(t, <-ready)
+	assert.Equal(t, ReadyStatus, tm.GetStatus(basicActor))
+}
+
+func TestActorContext_UpdateOwnStatus_InOrder(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddTransitionAction(basicActor, NoneStatus, ReadyStatus, func() error { return nil })
+	assert.NoError(t, err)
+	err = tm.AddTransitionAction(basicActor, ReadyStatus, StartedStatus, func() error { return nil })
+	assert.NoError(t, err)
+	started := make(chan bool)
+	err = tm.Subscribe(basicActor, StartedStatus, func() {
+		started <- true
+	})
+	assert.NoError(t, err)
+
+	Handle(tm, basicActor, func(ctx ActorContext, message testMessage) {
+		ctx.UpdateOwnStatus(ReadyStatus)
+		ctx.UpdateOwnStatus(StartedStatus)
+	})
+	err = tm.SendMessage(basicActor.GetKey(), testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+
+	assert.True(t, <-started)
+	assert.Equal(t, StartedStatus, tm.GetStatus(basicActor))
+}
+
+func TestActorContext_Spawn(t *testing.T) {
+	tm := NewSlashie()
+	parentActor := NewBasicActor("Actor", "Parent", tm)
+
+	childActor := actor.NewBasicActor("Actor", "Child")
+	children := make(chan actor.Key)
+	Handle(tm, parentActor, func(ctx ActorContext, message testMessage) {
+		children <- ctx.Spawn(childActor, NoneStatus, StoppedStatus)
+	})
+	err := tm.SendMessage(parentActor.GetKey(), testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, childActor.GetKey(), <-children)
+	// The child is registered with slashie.
+	assert.Equal(t, NoneStatus, tm.GetStatus(childActor))
+}
+
+func TestAddTransitionActorAction(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	contexts := make(chan ActorContext, 1)
+	err := tm.AddTransitionActorAction(basicActor, NoneStatus, ReadyStatus, func(ctx ActorContext) error {
+		// The context is only valid while the action is running.
+		assert.NoError(t, ctx.Context().Err())
+		contexts <- ctx
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+
+	ctx := <-contexts
+	assert.Equal(t, basicActor, ctx.Self())
+	assert.Equal(t, actor.Key(""), ctx.Sender())
+}
+
+func TestAddTransitionActorAction_IllegalTransition(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddTransitionActorAction(basicActor, ReadyStatus, NoneStatus, func(ctx ActorContext) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
+}
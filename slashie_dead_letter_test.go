@@ -0,0 +1,96 @@
+package slashie
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+// subscribeDeadLetters returns a channel which receives each dead letter.
+func subscribeDeadLetters(tm Slashie) chan actor.DeadLetter {
+	deadLetters := make(chan actor.DeadLetter, 10)
+	tm.SubscribeDeadLetters(func(deadLetter actor.DeadLetter) {
+		deadLetters <- deadLetter
+	})
+	return deadLetters
+}
+
+func TestDeadLetters_UnknownActor(t *testing.T) {
+	tm := NewSlashie()
+	deadLetters := subscribeDeadLetters(tm)
+
+	err := tm.SendMessage("Actor:Unknown", testMessage{message: "TestMessage"})
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
+
+	deadLetter := <-deadLetters
+	assert.Equal(t, actor.Key("Actor:Unknown"), deadLetter.ActorKey)
+	assert.Equal(t, testMessage{message: "TestMessage"}, deadLetter.Message)
+	assert.ErrorIs(t, deadLetter.Reason, actor.ErrUnknownActor)
+	assert.False(t, deadLetter.Timestamp.IsZero())
+	assert.Equal(t, uint64(1), tm.DeadLetterCount())
+}
+
+func TestDeadLetters_UnsupportedMessageType(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+	deadLetters := subscribeDeadLetters(tm)
+
+	// The message type is only checked once the actor receives the message.
+	err := tm.TrySendMessage(basicActor.GetKey(), testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+
+	deadLetter := <-deadLetters
+	assert.Equal(t, basicActor.GetKey(), deadLetter.ActorKey)
+	assert.Equal(t, testMessage{message: "TestMessage"}, deadLetter.Message)
+	assert.ErrorIs(t, deadLetter.Reason, actor.ErrUnsupportedMessageType)
+}
+
+func TestDeadLetters_ActorStopped(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+	deadLetters := subscribeDeadLetters(tm)
+
+	basicActor.Stop()
+	basicActor.Wait()
+
+	err := tm.SendMessage(basicActor.GetKey(), testMessage{message: "TestMessage"})
+	assert.ErrorIs(t, err, actor.ErrActorStopped)
+
+	deadLetter := <-deadLetters
+	assert.Equal(t, testMessage{message: "TestMessage"}, deadLetter.Message)
+	assert.ErrorIs(t, deadLetter.Reason, actor.ErrActorStopped)
+}
+
+func TestDeadLetters_MailboxDrop(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor := actor.NewBasicActor("Actor", "ActorA", actor.WithMailbox(actor.NewBoundedMailbox(1, actor.OverflowDropNewest)))
+	tm.AddActor(basicActor, NoneStatus, StoppedStatus)
+	deadLetters := subscribeDeadLetters(tm)
+
+	// Wait until the actor has been added, so that its dead letters are published.
+	assert.Equal(t, NoneStatus, tm.GetStatus(basicActor))
+
+	started := make(chan bool)
+	unblock := make(chan bool)
+	err := basicActor.TryNotify(func() {
+		started <- true
+		<-unblock
+	})
+	assert.NoError(t, err)
+	<-started
+
+	assert.NoError(t, basicActor.TrySendMessage(testMessage{message: "Queued"}))
+	assert.NoError(t, basicActor.TrySendMessage(testMessage{message: "TestMessage"}))
+
+	deadLetter := <-deadLetters
+	assert.Equal(t, testMessage{message: "TestMessage"}, deadLetter.Message)
+	assert.ErrorIs(t, deadLetter.Reason, actor.ErrMailboxFull)
+	// Dead letters from the actor are timestamped by slashie's clock.
+	assert.Equal(t, c.Now(), deadLetter.Timestamp)
+
+	close(unblock)
+}
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/strategicpause/slashie/actor"
 	"github.com/strategicpause/slashie/logger"
 	"github.com/stretchr/testify/assert"
 )
@@ -295,3 +296,134 @@ func TestSingleTransitionActorThreeDependencies(t *testing.T) {
 	assert.True(t, depBActorVisited)
 	assert.True(t, depCActorVisited)
 }
+
+// Removing the last dependency of an actor which is waiting to transition should immediately start the transition.
+func TestRemoveTransitionDependency_TriggersTransition(t *testing.T) {
+	s := NewSlashie()
+
+	srcActor, err := NewMultiTransitionActor(1, "Src", s)
+	assert.Nil(t, err)
+
+	depActor, err := NewMultiTransitionActor(1, "Dep", s)
+	assert.Nil(t, err)
+
+	err = s.AddTransitionDependency(srcActor, StatusA, depActor, StatusA)
+	assert.NoError(t, err)
+	assert.Equal(t, map[actor.Key]actor.Status{depActor.GetKey(): StatusA}, s.GetTransitionDependencies(srcActor, StatusA))
+	assert.Equal(t, map[actor.Key]actor.Status{srcActor.GetKey(): StatusA}, s.GetDependents(depActor, StatusA))
+
+	ch := make(chan bool)
+	err = s.Subscribe(srcActor, StatusA, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+
+	err = s.UpdateStatus(srcActor, StatusA)
+	assert.NoError(t, err)
+	assert.Equal(t, actor.Status(StatusInit), s.GetStatus(srcActor))
+
+	err = s.RemoveTransitionDependency(srcActor, StatusA, depActor, StatusA)
+	assert.NoError(t, err)
+
+	<-ch
+	assert.Equal(t, actor.Status(StatusA), s.GetStatus(srcActor))
+	assert.Empty(t, s.GetTransitionDependencies(srcActor, StatusA))
+	assert.Empty(t, s.GetDependents(depActor, StatusA))
+}
+
+// Removing a dependency on a parent status should start the transition of an actor waiting to enter one of its
+// children.
+func TestRemoveTransitionDependency_ParentStatus(t *testing.T) {
+	s := NewSlashie()
+	srcActor := NewBasicActor("Actor", "Src", s)
+	depActor := NewBasicActor("Actor", "Dep", s)
+
+	err := s.AddChildStatus(srcActor, RunningStatus, HealthyStatus)
+	assert.NoError(t, err)
+	err = s.AddTransitionAction(srcActor, NoneStatus, HealthyStatus, func() error { return nil })
+	assert.NoError(t, err)
+	err = s.AddTransitionAction(depActor, NoneStatus, ReadyStatus, func() error { return nil })
+	assert.NoError(t, err)
+	err = s.AddTransitionDependency(srcActor, RunningStatus, depActor, ReadyStatus)
+	assert.NoError(t, err)
+
+	ch := make(chan bool)
+	err = s.Subscribe(srcActor, HealthyStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+
+	err = s.UpdateStatus(srcActor, HealthyStatus)
+	assert.NoError(t, err)
+	assert.Equal(t, NoneStatus, s.GetStatus(srcActor))
+
+	err = s.RemoveTransitionDependency(srcActor, RunningStatus, depActor, ReadyStatus)
+	assert.NoError(t, err)
+
+	<-ch
+	assert.Equal(t, HealthyStatus, s.GetStatus(srcActor))
+}
+
+// Removing a dependency of an actor which is already transitioning should not start the transition again.
+func TestRemoveTransitionDependency_InFlightTransition(t *testing.T) {
+	s := NewSlashie()
+	srcActor := NewBasicActor("Actor", "Src", s)
+	depActor := NewBasicActor("Actor", "Dep", s)
+
+	started := make(chan bool, 2)
+	unblock := make(chan bool)
+	calls := 0
+	err := s.AddTransitionAction(srcActor, NoneStatus, ReadyStatus, func() error {
+		calls += 1
+		started <- true
+		<-unblock
+		return nil
+	})
+	assert.NoError(t, err)
+	ch := make(chan bool)
+	err = s.Subscribe(srcActor, ReadyStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+
+	err = s.UpdateStatus(srcActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-started
+
+	err = s.AddTransitionDependency(srcActor, ReadyStatus, depActor, ReadyStatus)
+	assert.NoError(t, err)
+	err = s.RemoveTransitionDependency(srcActor, ReadyStatus, depActor, ReadyStatus)
+	assert.NoError(t, err)
+	close(unblock)
+
+	<-ch
+	assert.Equal(t, ReadyStatus, s.GetStatus(srcActor))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRemoveTransitionDependency_UnknownDependency(t *testing.T) {
+	s := NewSlashie()
+
+	srcActor, err := NewMultiTransitionActor(1, "Src", s)
+	assert.Nil(t, err)
+
+	depActor, err := NewMultiTransitionActor(1, "Dep", s)
+	assert.Nil(t, err)
+
+	err = s.RemoveTransitionDependency(srcActor, StatusA, depActor, StatusA)
+	assert.Error(t, err)
+}
+
+func TestRemoveTransitionDependency_UnknownActor(t *testing.T) {
+	s := NewSlashie()
+
+	srcActor := actor.NewBasicActor("Actor", "src")
+	depActor, err := NewMultiTransitionActor(1, "Dep", s)
+	assert.Nil(t, err)
+
+	err = s.RemoveTransitionDependency(srcActor, StatusA, depActor, StatusA)
+	assert.Error(t, err)
+
+	err = s.RemoveTransitionDependency(depActor, StatusA, srcActor, StatusA)
+	assert.Error(t, err)
+}
@@ -1,11 +1,13 @@
 package slashie
 
 import (
+	"errors"
 	"sync"
 	"testing"
 
 	"github.com/strategicpause/slashie/actor"
 	"github.com/strategicpause/slashie/logger"
+	"github.com/strategicpause/slashie/transition"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,3 +109,76 @@ func TestMultiTransitionActor(t *testing.T) {
 	assert.True(t, visitedB)
 	assert.True(t, visitedC)
 }
+
+// TestMultiHopTransition will validate that an actor can transition to a status which is not directly reachable,
+// visiting each intermediate status along the way.
+func TestMultiHopTransition(t *testing.T) {
+	s := NewSlashie(WithMultiHopTransitions())
+	a := actor.NewBasicActor(ActorType, "Id")
+	s.AddActor(a, StatusInit, StatusC)
+
+	noop := func() error { return nil }
+	err := s.AddTransitionActions(a, []*transition.TransitionAction{
+		{SrcStatus: StatusInit, DestStatus: StatusA, Action: noop},
+		{SrcStatus: StatusA, DestStatus: StatusB, Action: noop},
+		{SrcStatus: StatusB, DestStatus: StatusC, Action: noop},
+	})
+	assert.NoError(t, err)
+
+	// Subscriptions for intermediate statuses are handled.
+	var visited []actor.Status
+	for _, status := range Statuses {
+		status := status
+		err = s.Subscribe(a, status, func() {
+			visited = append(visited, status)
+		})
+		assert.NoError(t, err)
+	}
+
+	err = s.UpdateStatus(a, StatusC)
+	assert.NoError(t, err)
+
+	a.Wait()
+	assert.Equal(t, Statuses, visited)
+}
+
+// TestMultiHopTransition_FailedHop will validate that an actor stops at the first transition which fails.
+func TestMultiHopTransition_FailedHop(t *testing.T) {
+	s := NewSlashie(WithMultiHopTransitions())
+	a := actor.NewBasicActor(ActorType, "Id")
+	s.AddActor(a, StatusInit, StatusC)
+
+	noop := func() error { return nil }
+	err := s.AddTransitionActions(a, []*transition.TransitionAction{
+		{SrcStatus: StatusInit, DestStatus: StatusA, Action: noop},
+		{SrcStatus: StatusA, DestStatus: StatusB, Action: func() error {
+			return errors.New("failed to transition")
+		}},
+		{SrcStatus: StatusB, DestStatus: StatusC, Action: noop},
+		{SrcStatus: StatusA, DestStatus: StatusC, Action: noop},
+	})
+	assert.NoError(t, err)
+
+	bVisited := false
+	err = s.Subscribe(a, StatusB, func() {
+		bVisited = true
+	})
+	assert.NoError(t, err)
+
+	err = s.UpdateStatus(a, StatusB)
+	assert.NoError(t, err)
+
+	// The failed transition moves the actor to its terminal status.
+	a.Wait()
+	assert.False(t, bVisited)
+	assert.Equal(t, actor.Status(StatusC), s.GetStatus(a))
+}
+
+func TestMultiHopTransition_Disabled(t *testing.T) {
+	s := NewSlashie()
+	a, err := NewMultiTransitionActor(NumTransitions, "Id", s)
+	assert.Nil(t, err)
+
+	err = s.UpdateStatus(a, StatusC)
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
+}
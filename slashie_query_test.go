@@ -0,0 +1,69 @@
+package slashie
+
+import (
+	"testing"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+// transitionTo moves the given actor directly to the given status, and waits until it has transitioned.
+func transitionTo(t *testing.T, tm Slashie, a actor.Actor, status actor.Status) {
+	err := tm.AddTransitionAction(a, tm.GetStatus(a), status, func() error { return nil })
+	assert.NoError(t, err)
+	transitioned := make(chan bool)
+	err = tm.Subscribe(a, status, func() {
+		transitioned <- true
+	})
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(a, status)
+	assert.NoError(t, err)
+	<-transitioned
+}
+
+func TestFindActors(t *testing.T) {
+	tm := NewSlashie()
+	workerA := NewBasicActor("Worker", "WorkerA", tm)
+	workerB := NewBasicActor("Worker", "WorkerB", tm)
+	other := NewBasicActor("Other", "OtherA", tm)
+	transitionTo(t, tm, workerB, ReadyStatus)
+	transitionTo(t, tm, other, ReadyStatus)
+
+	actors, err := tm.FindActors(ActorQuery{})
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Actor{workerA, workerB, other}, actors)
+
+	actors, err = tm.FindActors(ActorQuery{Type: "Worker"})
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Actor{workerA, workerB}, actors)
+
+	actors, err = tm.FindActors(ActorQuery{Status: ReadyStatus})
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Actor{workerB, other}, actors)
+
+	actors, err = tm.FindActors(ActorQuery{Type: "Worker", Status: ReadyStatus})
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Actor{workerB}, actors)
+
+	actors, err = tm.FindActors(ActorQuery{KeyPattern: "*:*A"})
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Actor{workerA, other}, actors)
+
+	_, err = tm.FindActors(ActorQuery{KeyPattern: "Worker:["})
+	assert.ErrorIs(t, err, actor.ErrInvalidKeyPattern)
+}
+
+func TestFindActors_ChildStatus(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+	err := tm.AddChildStatus(basicActor, RunningStatus, HealthyStatus)
+	assert.NoError(t, err)
+	transitionTo(t, tm, basicActor, HealthyStatus)
+
+	// Actors in a nested status are also in its parent status.
+	for _, status := range []actor.Status{HealthyStatus, RunningStatus} {
+		actors, err := tm.FindActors(ActorQuery{Status: status})
+		assert.NoError(t, err)
+		assert.Equal(t, []actor.Actor{basicActor}, actors)
+	}
+}
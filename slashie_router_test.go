@@ -0,0 +1,90 @@
+package slashie
+
+import (
+	"testing"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/router"
+	"github.com/stretchr/testify/assert"
+)
+
+// newWorker adds an actor of type Worker which passes its Id to the given channel for each testMessage it receives.
+func newWorker(tm Slashie, actorId actor.Id, received chan<- actor.Id) actor.Actor {
+	worker := NewBasicActor("Worker", actorId, tm)
+	worker.RegisterMessageHandler(testMessageType, func(msg any) {
+		received <- actorId
+	})
+	return worker
+}
+
+func TestRouter(t *testing.T) {
+	tm := NewSlashie()
+	received := make(chan actor.Id, 10)
+	// Actors which were added before the router join its pool.
+	newWorker(tm, "WorkerA", received)
+	NewBasicActor("Other", "OtherA", tm)
+
+	r := router.NewRouter("Router", "RouterA", "Worker", router.NewRoundRobin())
+	tm.AddActor(r, NoneStatus, StoppedStatus)
+	// Actors which are added after the router join its pool.
+	newWorker(tm, "WorkerB", received)
+
+	err := tm.SendMessage(r.GetKey(), testMessage{message: "first"})
+	assert.NoError(t, err)
+	err = tm.SendMessage(r.GetKey(), testMessage{message: "second"})
+	assert.NoError(t, err)
+	// Each worker handles its message on its own goroutine, so they may be received in either order.
+	assert.ElementsMatch(t, []actor.Id{"WorkerA", "WorkerB"}, []actor.Id{<-received, <-received})
+}
+
+func TestRouter_TerminalStatus(t *testing.T) {
+	tm := NewSlashie()
+	received := make(chan actor.Id, 10)
+	workerA := newWorker(tm, "WorkerA", received)
+	newWorker(tm, "WorkerB", received)
+
+	r := router.NewRouter("Router", "RouterA", "Worker", router.NewBroadcast())
+	tm.AddActor(r, NoneStatus, StoppedStatus)
+
+	// Actors leave the pool once they reach their terminal status.
+	err := tm.AddTransitionAction(workerA, NoneStatus, StoppedStatus, func() error { return nil })
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(workerA, StoppedStatus)
+	assert.NoError(t, err)
+	workerA.Wait()
+	assert.Equal(t, StoppedStatus, tm.GetStatus(workerA))
+
+	err = tm.SendMessage(r.GetKey(), testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+	assert.Equal(t, actor.Id("WorkerB"), <-received)
+	assert.Len(t, r.Routees(), 1)
+}
+
+func TestRouter_BlockedRoutee(t *testing.T) {
+	tm := NewSlashie()
+	received := make(chan actor.Id, 10)
+	blocked := NewBasicActor("Worker", "Blocked", tm)
+	newWorker(tm, "WorkerB", received)
+	r := router.NewRouter("Router", "RouterA", "Worker", router.NewBroadcast())
+	tm.AddActor(r, NoneStatus, StoppedStatus)
+
+	started := make(chan bool)
+	unblock := make(chan bool)
+	blocked.RegisterMessageHandler(testMessageType, func(msg any) {
+		started <- true
+		<-unblock
+	})
+	assert.NoError(t, blocked.SendMessage(testMessage{message: "First"}))
+	<-started
+
+	// The message is forwarded outside the coordinator, so slashie is not held up by the blocked routee.
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- tm.SendMessage(r.GetKey(), testMessage{message: "TestMessage"})
+	}()
+	assert.Equal(t, NoneStatus, tm.GetStatus(r))
+
+	close(unblock)
+	assert.NoError(t, <-errChan)
+	assert.Equal(t, actor.Id("WorkerB"), <-received)
+}
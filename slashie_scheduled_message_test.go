@@ -0,0 +1,168 @@
+package slashie
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/clock"
+	"github.com/strategicpause/slashie/scheduler"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMessageActor creates an actor which passes each testMessage it receives to the returned channel.
+func newMessageActor(tm Slashie) (actor.Actor, chan string) {
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	messages := make(chan string, 10)
+	basicActor.RegisterMessageHandler(testMessageType, func(msg any) {
+		messages <- msg.(testMessage).message
+	})
+
+	return basicActor, messages
+}
+
+func TestSendMessageAfter(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor, messages := newMessageActor(tm)
+
+	_, err := tm.SendMessageAfter(basicActor.GetKey(), 2*time.Second, testMessage{message: "second"})
+	assert.NoError(t, err)
+	_, err = tm.SendMessageAt(basicActor.GetKey(), c.Now().Add(time.Second), testMessage{message: "first"})
+	assert.NoError(t, err)
+	// Only a single timer is used for all scheduled messages.
+	assert.Equal(t, 1, c.NumTimers())
+
+	c.Advance(time.Second)
+	assert.Equal(t, "first", <-messages)
+	c.Advance(time.Second)
+	assert.Equal(t, "second", <-messages)
+	assert.Empty(t, messages)
+}
+
+func TestSendMessageAfter_DeliveredInOrder(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor, messages := newMessageActor(tm)
+
+	var expected []string
+	for i := 1; i <= cap(messages); i++ {
+		message := fmt.Sprintf("message-%d", i)
+		expected = append(expected, message)
+		_, err := tm.SendMessageAfter(basicActor.GetKey(), time.Duration(i)*time.Second, testMessage{message: message})
+		assert.NoError(t, err)
+	}
+	// Each message becomes due in its own batch, and no batch waits for the previous one to be delivered.
+	for range expected {
+		c.Advance(time.Second)
+		tm.GetStatus(basicActor)
+	}
+
+	var received []string
+	for range expected {
+		received = append(received, <-messages)
+	}
+	assert.Equal(t, expected, received)
+}
+
+func TestSendMessageAfter_BusyActor(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+
+	busyActor := NewBasicActor("Actor", "Busy", tm)
+	unblock := make(chan bool)
+	busyActor.RegisterMessageHandler(testMessageType, func(msg any) {
+		<-unblock
+	})
+	basicActor, messages := newMessageActor(tm)
+
+	for i := 0; i < 3; i++ {
+		_, err := tm.SendMessageAfter(busyActor.GetKey(), time.Second, testMessage{message: "busy"})
+		assert.NoError(t, err)
+	}
+	_, err := tm.SendMessageAfter(basicActor.GetKey(), time.Second, testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+
+	// An actor which is stuck handling a scheduled message does not hold up delivery to the other actors.
+	c.Advance(time.Second)
+	assert.Equal(t, "TestMessage", <-messages)
+	close(unblock)
+}
+
+func TestSendMessageAfter_Cancel(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor, messages := newMessageActor(tm)
+
+	handle, err := tm.SendMessageAfter(basicActor.GetKey(), time.Second, testMessage{message: "cancelled"})
+	assert.NoError(t, err)
+	_, err = tm.SendMessageAfter(basicActor.GetKey(), 2*time.Second, testMessage{message: "delivered"})
+	assert.NoError(t, err)
+
+	assert.True(t, handle.Cancel())
+	assert.False(t, handle.Cancel())
+
+	c.Advance(2 * time.Second)
+	assert.Equal(t, "delivered", <-messages)
+	assert.Empty(t, messages)
+}
+
+func TestSendMessageEvery(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor, messages := newMessageActor(tm)
+
+	handle, err := tm.SendMessageEvery(basicActor.GetKey(), time.Second, testMessage{message: "tick"})
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		c.Advance(time.Second)
+		assert.Equal(t, "tick", <-messages)
+	}
+
+	assert.True(t, handle.Cancel())
+	assert.Equal(t, 0, c.NumTimers())
+}
+
+func TestSendMessageEvery_ActorStopped(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor, messages := newMessageActor(tm)
+
+	err := tm.AddTransitionAction(basicActor, NoneStatus, StoppedStatus, func() error { return nil })
+	assert.NoError(t, err)
+	handle, err := tm.SendMessageEvery(basicActor.GetKey(), time.Second, testMessage{message: "tick"})
+	assert.NoError(t, err)
+
+	c.Advance(time.Second)
+	assert.Equal(t, "tick", <-messages)
+
+	err = tm.UpdateStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+	basicActor.Wait()
+
+	// Scheduled messages are cancelled once the actor has stopped.
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+	assert.False(t, handle.Cancel())
+	assert.Equal(t, 0, c.NumTimers())
+
+	_, err = tm.SendMessageAfter(basicActor.GetKey(), time.Second, testMessage{message: "tick"})
+	assert.ErrorIs(t, err, actor.ErrActorStopped)
+}
+
+func TestSendMessageAfter_UnknownActor(t *testing.T) {
+	tm := NewSlashie()
+
+	_, err := tm.SendMessageAfter("Actor:Unknown", time.Second, testMessage{})
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
+}
+
+func TestSendMessageEvery_InvalidInterval(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	_, err := tm.SendMessageEvery(basicActor.GetKey(), 0, testMessage{})
+	assert.ErrorIs(t, err, scheduler.ErrInvalidInterval)
+}
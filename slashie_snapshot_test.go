@@ -0,0 +1,91 @@
+package slashie
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	srcActor := NewBasicActor("SourceActor", "ActorA", tm)
+	depActor := NewBasicActor("DependentActor", "ActorB", tm)
+
+	noop := func() error { return nil }
+	err := tm.AddTransitionAction(srcActor, NoneStatus, ReadyStatus, noop)
+	assert.NoError(t, err)
+	err = tm.AddTransitionDependency(srcActor, ReadyStatus, depActor, ReadyStatus)
+	assert.NoError(t, err)
+	err = tm.Subscribe(srcActor, ReadyStatus, func() {})
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(srcActor, ReadyStatus)
+	assert.NoError(t, err)
+
+	// The dependent actor is blocked in its transition action until unblock is closed.
+	started := make(chan bool)
+	unblock := make(chan bool)
+	err = tm.AddTransitionAction(depActor, NoneStatus, ReadyStatus, func() error {
+		started <- true
+		<-unblock
+		return nil
+	})
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(depActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-started
+	depActor.Notify(func() {})
+
+	snapshot := tm.Snapshot()
+	assert.Equal(t, c.Now(), snapshot.Timestamp)
+	assert.Equal(t, []ActorSnapshot{
+		{
+			Key:            srcActor.GetKey(),
+			Type:           "SourceActor",
+			Id:             "ActorA",
+			InitialStatus:  NoneStatus,
+			TerminalStatus: StoppedStatus,
+			KnownStatus:    NoneStatus,
+			DesiredStatus:  ReadyStatus,
+			Dependencies: map[actor.Status]map[actor.Key]actor.Status{
+				ReadyStatus: {depActor.GetKey(): ReadyStatus},
+			},
+			Subscriptions: map[actor.Status]int{ReadyStatus: 1},
+		},
+		{
+			Key:            depActor.GetKey(),
+			Type:           "DependentActor",
+			Id:             "ActorB",
+			InitialStatus:  NoneStatus,
+			TerminalStatus: StoppedStatus,
+			KnownStatus:    NoneStatus,
+			DesiredStatus:  ReadyStatus,
+			Transition: &TransitionSnapshot{
+				SrcStatus:          NoneStatus,
+				DestStatus:         ReadyStatus,
+				OutstandingActions: 1,
+			},
+			Dependencies:  map[actor.Status]map[actor.Key]actor.Status{},
+			Subscriptions: map[actor.Status]int{},
+			MailboxLen:    1,
+		},
+	}, snapshot.Actors)
+
+	// The snapshot can be serialized.
+	_, err = json.Marshal(snapshot)
+	assert.NoError(t, err)
+
+	// Once the dependent actor transitions, the source actor is no longer waiting on it.
+	ready := make(chan bool)
+	err = tm.Subscribe(srcActor, ReadyStatus, func() {
+		ready <- true
+	})
+	assert.NoError(t, err)
+	close(unblock)
+	<-ready
+	assert.Empty(t, tm.Snapshot().Actors[0].Dependencies)
+}
@@ -1,12 +1,15 @@
 package slashie
 
 import (
+	"context"
 	"errors"
 	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/dependency"
 	"github.com/strategicpause/slashie/transition"
 	"github.com/stretchr/testify/assert"
 	"sync"
 	"testing"
+	"time"
 )
 
 const (
@@ -118,7 +121,7 @@ func TestUpdateStatus_UnknownActor(t *testing.T) {
 
 	err := tm.UpdateStatus(basicActor, ReadyStatus)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
 }
 
 func TestGetStatus(t *testing.T) {
@@ -222,7 +225,10 @@ func TestAddTransitionDependency_CircularDependency(t *testing.T) {
 	assert.NoError(t, err)
 
 	err = tm.AddTransitionDependency(actorC, ReadyStatus, actorA, ReadyStatus)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, dependency.ErrDependencyCycle)
+	var cycleErr *dependency.CycleError
+	assert.ErrorAs(t, err, &cycleErr)
+	assert.Len(t, cycleErr.Path, 4)
 }
 
 // Verify that the code does result in a false positive when two actors have a dependency
@@ -259,7 +265,7 @@ func TestAddTransitionCallback_SameState(t *testing.T) {
 	basicActor := NewBasicActor("Actor", "ActorA", tm)
 
 	err := tm.AddTransitionAction(basicActor, ReadyStatus, ReadyStatus, func() error { return nil })
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
 }
 
 // Verify that a callback cannot be added to the initial status.
@@ -341,12 +347,97 @@ func TestSendMessage(t *testing.T) {
 	assert.True(t, messageHandled)
 }
 
+func TestAsk(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+	basicActor.RegisterReplyHandler(testMessageType, func(msg any) (any, error) {
+		if msg.(testMessage).message == "" {
+			return nil, errors.New("empty message")
+		}
+		return msg.(testMessage).message + "Reply", nil
+	})
+
+	reply, err := tm.Ask(context.Background(), basicActor.GetKey(), testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+	assert.Equal(t, "TestMessageReply", reply)
+
+	_, err = tm.Ask(context.Background(), basicActor.GetKey(), testMessage{})
+	assert.EqualError(t, err, "could not ask Actor:ActorA: empty message")
+
+	_, err = tm.Ask(context.Background(), "Actor:Unknown", testMessage{})
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
+}
+
+func TestTrySendMessage(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := actor.NewBasicActor("Actor", "ActorA", actor.WithMailboxSize(1))
+	tm.AddActor(basicActor, NoneStatus, StoppedStatus)
+
+	messages := make(chan string)
+	basicActor.RegisterMessageHandler(testMessageType, func(msg any) {
+		messages <- msg.(testMessage).message
+	})
+
+	err := tm.TrySendMessageUntil(basicActor.GetKey(), time.Now().Add(time.Minute), testMessage{message: "first"})
+	assert.NoError(t, err)
+	// The actor is blocked handling the first message, so the mailbox fills up after the second message.
+	err = tm.TrySendMessageUntil(basicActor.GetKey(), time.Now().Add(time.Minute), testMessage{message: "second"})
+	assert.NoError(t, err)
+	err = tm.TrySendMessage(basicActor.GetKey(), testMessage{message: "third"})
+	assert.ErrorIs(t, err, actor.ErrMailboxFull)
+
+	assert.Equal(t, "first", <-messages)
+	assert.Equal(t, "second", <-messages)
+
+	err = tm.TrySendMessage("Actor:Unknown", testMessage{})
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
+}
+
+func TestTrySendMessage_CoordinatorBusy(t *testing.T) {
+	tm := NewSlashie(WithMailboxSize(1))
+	basicActor, messages := newMessageActor(tm)
+
+	// Block the coordinator, and fill its mailbox.
+	unblock := make(chan struct{})
+	tm.(*slashie).mailbox <- func() { <-unblock }
+	tm.(*slashie).mailbox <- func() {}
+
+	// A zero deadline does not wait on the coordinator.
+	err := tm.TrySendMessage(basicActor.GetKey(), testMessage{message: "first"})
+	assert.NoError(t, err)
+	assert.Equal(t, "first", <-messages)
+	err = tm.TrySendMessage("Actor:Unknown", testMessage{})
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
+
+	err = tm.TrySendMessageUntil(basicActor.GetKey(), time.Now().Add(10*time.Millisecond), testMessage{message: "second"})
+	assert.ErrorIs(t, err, actor.ErrCoordinatorBusy)
+	assert.EqualError(t, err, "slashie coordinator is too busy to look up actor Actor:ActorA")
+
+	close(unblock)
+	err = tm.TrySendMessageUntil(basicActor.GetKey(), time.Now().Add(time.Minute), testMessage{message: "third"})
+	assert.NoError(t, err)
+	assert.Equal(t, "third", <-messages)
+}
+
+func TestAddActor_DeferredStart(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := actor.NewBasicActor("Actor", "ActorA", actor.WithDeferredStart())
+
+	processed := make(chan bool)
+	basicActor.Notify(func() {
+		processed <- true
+	})
+
+	// The actor begins processing messages once it has been added.
+	tm.AddActor(basicActor, NoneStatus, StoppedStatus)
+	assert.True(t, <-processed)
+}
+
 func TestSendMessage_ActorDoesNotExist(t *testing.T) {
 	tm := NewSlashie()
 
 	err := tm.SendMessage("unknownActor", testMessage{message: "message"})
-	assert.NotNil(t, err)
-
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
 }
 
 func TestSendMessage_InvalidType(t *testing.T) {
@@ -354,5 +445,599 @@ func TestSendMessage_InvalidType(t *testing.T) {
 	basicActor := NewBasicActor("Actor", "ActorA", tm)
 
 	err := tm.SendMessage(basicActor.GetKey(), testMessage{message: "message"})
-	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, actor.ErrUnsupportedMessageType)
+
+	var typeErr *actor.UnsupportedMessageTypeError
+	assert.ErrorAs(t, err, &typeErr)
+	assert.Equal(t, basicActor.GetKey(), typeErr.ActorKey)
+}
+
+func TestSubscribe_InvalidStatus(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	// An actor cannot subscribe to its current status.
+	err := tm.Subscribe(basicActor, NoneStatus, func() {})
+	assert.ErrorIs(t, err, actor.ErrInvalidSubscriptionStatus)
+}
+
+func TestUpdateStatus_IllegalTransition(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.UpdateStatus(basicActor, ReadyStatus)
+
+	var transitionErr *actor.IllegalTransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, NoneStatus, transitionErr.SrcStatus)
+	assert.Equal(t, ReadyStatus, transitionErr.DestStatus)
+}
+
+func TestAddTransitionGuard(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddTransitionAction(basicActor, NoneStatus, ReadyStatus, func() error { return nil })
+	assert.NoError(t, err)
+
+	configLoaded := false
+	err = tm.AddTransitionGuard(basicActor, NoneStatus, ReadyStatus, func() bool {
+		return configLoaded
+	})
+	assert.NoError(t, err)
+
+	// The guard rejects the transition, so the status should remain unchanged.
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.ErrorIs(t, err, actor.ErrTransitionRejected)
+	assert.Equal(t, NoneStatus, tm.GetStatus(basicActor))
+
+	ch := make(chan bool)
+	err = tm.Subscribe(basicActor, ReadyStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+
+	// Guards are evaluated on the actor's goroutine, so state is modified there as well.
+	basicActor.Notify(func() {
+		configLoaded = true
+	})
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+
+	<-ch
+	assert.Equal(t, ReadyStatus, tm.GetStatus(basicActor))
+}
+
+func TestAddTransitionGuard_UpdatesQueuedWhileEvaluating(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	noop := func() error { return nil }
+	err := tm.AddTransitionActions(basicActor, []*transition.TransitionAction{
+		{SrcStatus: NoneStatus, DestStatus: ReadyStatus, Action: noop},
+		{SrcStatus: NoneStatus, DestStatus: StoppedStatus, Action: noop},
+		{SrcStatus: ReadyStatus, DestStatus: StoppedStatus, Action: noop},
+	})
+	assert.NoError(t, err)
+
+	evaluating := make(chan bool)
+	unblock := make(chan bool)
+	err = tm.AddTransitionGuard(basicActor, NoneStatus, ReadyStatus, func() bool {
+		evaluating <- true
+		<-unblock
+		return true
+	})
+	assert.NoError(t, err)
+
+	statuses := make(chan actor.Status, 2)
+	for _, status := range []actor.Status{ReadyStatus, StoppedStatus} {
+		status := status
+		err = tm.Subscribe(basicActor, status, func() {
+			statuses <- status
+		})
+		assert.NoError(t, err)
+	}
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- tm.UpdateStatus(basicActor, ReadyStatus)
+	}()
+	<-evaluating
+
+	// The update arrives while the guards are being evaluated, so it is applied after the first update.
+	err = tm.UpdateStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+	close(unblock)
+	assert.NoError(t, <-errChan)
+
+	assert.Equal(t, ReadyStatus, <-statuses)
+	assert.Equal(t, StoppedStatus, <-statuses)
+}
+
+func TestAddTransitionGuard_UpdatesQueuedWhileRejecting(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	noop := func() error { return nil }
+	err := tm.AddTransitionActions(basicActor, []*transition.TransitionAction{
+		{SrcStatus: NoneStatus, DestStatus: ReadyStatus, Action: noop},
+		{SrcStatus: NoneStatus, DestStatus: StoppedStatus, Action: noop},
+	})
+	assert.NoError(t, err)
+
+	evaluating := make(chan bool)
+	unblock := make(chan bool)
+	err = tm.AddTransitionGuard(basicActor, NoneStatus, ReadyStatus, func() bool {
+		evaluating <- true
+		<-unblock
+		return false
+	})
+	assert.NoError(t, err)
+
+	ch := make(chan bool)
+	err = tm.Subscribe(basicActor, StoppedStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- tm.UpdateStatus(basicActor, ReadyStatus)
+	}()
+	<-evaluating
+
+	// Once the guards reject the first update, the queued update is applied.
+	err = tm.UpdateStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+	close(unblock)
+	assert.ErrorIs(t, <-errChan, actor.ErrTransitionRejected)
+
+	<-ch
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+}
+
+func TestAddTransitionGuard_ActorStopped(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddTransitionAction(basicActor, NoneStatus, ReadyStatus, func() error { return nil })
+	assert.NoError(t, err)
+	err = tm.AddTransitionGuard(basicActor, NoneStatus, ReadyStatus, func() bool { return true })
+	assert.NoError(t, err)
+
+	// The guards cannot be evaluated once the actor has stopped.
+	basicActor.Stop()
+	basicActor.Wait()
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.ErrorIs(t, err, actor.ErrActorStopped)
+	assert.Equal(t, NoneStatus, tm.GetStatus(basicActor))
+}
+
+func TestAddTransitionGuard_IllegalTransition(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddTransitionGuard(basicActor, ReadyStatus, NoneStatus, func() bool { return true })
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
+}
+
+func TestOnEnterOnExit(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	// Events are only modified on the actor's goroutine.
+	var events []string
+	err := tm.AddTransitionActions(basicActor, []*transition.TransitionAction{
+		{SrcStatus: NoneStatus, DestStatus: ReadyStatus, Action: func() error {
+			events = append(events, "action:ready")
+			return nil
+		}},
+		{SrcStatus: ReadyStatus, DestStatus: StoppedStatus, Action: func() error {
+			events = append(events, "action:stopped")
+			return nil
+		}},
+	})
+	assert.NoError(t, err)
+
+	err = tm.OnEnter(basicActor, ReadyStatus, func() {
+		events = append(events, "enter:ready")
+	})
+	assert.NoError(t, err)
+	err = tm.OnExit(basicActor, ReadyStatus, func() {
+		events = append(events, "exit:ready")
+	})
+	assert.NoError(t, err)
+
+	ch := make(chan bool)
+	err = tm.Subscribe(basicActor, ReadyStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-ch
+
+	err = tm.UpdateStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+	basicActor.Wait()
+
+	assert.Equal(t, []string{"action:ready", "enter:ready", "exit:ready", "action:stopped"}, events)
+}
+
+func TestOnEnter_UnknownActor(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := actor.NewBasicActor("Actor", "ActorA")
+
+	err := tm.OnEnter(basicActor, ReadyStatus, func() {})
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
+
+	err = tm.OnExit(basicActor, ReadyStatus, func() {})
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
+}
+
+func TestAddWildcardTransitionAction(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddTransitionActions(basicActor, []*transition.TransitionAction{
+		{SrcStatus: NoneStatus, DestStatus: ReadyStatus, Action: func() error {
+			return nil
+		}},
+		{SrcStatus: transition.AnyStatus, DestStatus: StoppedStatus, ExcludedStatuses: []actor.Status{NoneStatus},
+			Action: func() error {
+				return nil
+			}},
+	})
+	assert.NoError(t, err)
+
+	// NONE is excluded from the wildcard transition.
+	err = tm.UpdateStatus(basicActor, StoppedStatus)
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
+
+	ch := make(chan bool)
+	err = tm.Subscribe(basicActor, ReadyStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-ch
+
+	err = tm.UpdateStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+	basicActor.Wait()
+
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+}
+
+// Verify that a wildcard action cannot be added for a transition to the initial status.
+func TestAddWildcardTransitionAction_StartState(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddWildcardTransitionAction(basicActor, NoneStatus, func() error { return nil })
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
+}
+
+func TestCancelTransition(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	started := make(chan bool)
+	reasons := make(chan string, 1)
+	err := tm.AddTransitionContextAction(basicActor, NoneStatus, ReadyStatus, func(ctx context.Context) error {
+		started <- true
+		<-ctx.Done()
+		reasons <- transition.CancelReason(ctx)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-started
+
+	err = tm.CancelTransition(basicActor, "no longer needed")
+	assert.NoError(t, err)
+	assert.Equal(t, "no longer needed", <-reasons)
+
+	// The actor should remain in its known status, and is no longer transitioning.
+	assert.Equal(t, NoneStatus, tm.GetStatus(basicActor))
+	err = tm.CancelTransition(basicActor, "no longer needed")
+	assert.ErrorIs(t, err, actor.ErrNotTransitioning)
+}
+
+func TestCancelTransition_CancelledStatus(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	started := make(chan bool)
+	err := tm.AddTransitionContextAction(basicActor, NoneStatus, ReadyStatus, func(ctx context.Context) error {
+		started <- true
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.NoError(t, err)
+	err = tm.SetCancelledStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-started
+
+	err = tm.CancelTransition(basicActor, "shutting down")
+	assert.NoError(t, err)
+
+	// The cancelled status is the terminal status, so the actor will stop.
+	basicActor.Wait()
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+}
+
+func TestCancelTransition_ExitHooks(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	// The first attempt blocks until it is cancelled.
+	started := make(chan bool, 1)
+	attempts := 0
+	err := tm.AddTransitionContextAction(basicActor, NoneStatus, ReadyStatus, func(ctx context.Context) error {
+		attempts += 1
+		if attempts > 1 {
+			return nil
+		}
+		started <- true
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.NoError(t, err)
+	// Hooks are only modified on the actor's goroutine.
+	exits := 0
+	err = tm.OnExit(basicActor, NoneStatus, func() {
+		exits += 1
+	})
+	assert.NoError(t, err)
+	ready := make(chan bool)
+	err = tm.Subscribe(basicActor, ReadyStatus, func() {
+		ready <- true
+	})
+	assert.NoError(t, err)
+
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-started
+	err = tm.CancelTransition(basicActor, "retry")
+	assert.NoError(t, err)
+	assert.Equal(t, NoneStatus, tm.GetStatus(basicActor))
+
+	// The actor never left NONE, so its exit hooks are not executed again.
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-ready
+	assert.Equal(t, 1, exits)
+}
+
+func TestCancelTransition_CancelledStatusWhileWaitingOnDependencies(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+	depActor := NewBasicActor("Actor", "ActorB", tm)
+
+	err := tm.AddTransitionAction(basicActor, NoneStatus, ReadyStatus, func() error { return nil })
+	assert.NoError(t, err)
+	err = tm.AddTransitionDependency(basicActor, ReadyStatus, depActor, ReadyStatus)
+	assert.NoError(t, err)
+	err = tm.SetCancelledStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+	exited := make(chan bool, 1)
+	err = tm.OnExit(basicActor, NoneStatus, func() {
+		exited <- true
+	})
+	assert.NoError(t, err)
+
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	err = tm.CancelTransition(basicActor, "shutting down")
+	assert.NoError(t, err)
+
+	// No transition was started, so the exit hooks are executed when the actor moves to the cancelled status.
+	basicActor.Wait()
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+	assert.Len(t, exited, 1)
+}
+
+func TestSetCancelledStatus_InitialStatus(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.SetCancelledStatus(basicActor, NoneStatus)
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
+}
+
+const (
+	StartedStatus  actor.Status = "STARTED"
+	DegradedStatus actor.Status = "DEGRADED"
+)
+
+// newBlockingActor creates an actor which blocks while transitioning from NONE to READY until the returned channel
+// is closed. From READY, the actor can transition to STARTED, DEGRADED or STOPPED.
+func newBlockingActor(t *testing.T, tm Slashie) (actor.Actor, chan bool) {
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	unblock := make(chan bool)
+	noop := func() error { return nil }
+	err := tm.AddTransitionActions(basicActor, []*transition.TransitionAction{
+		{SrcStatus: NoneStatus, DestStatus: ReadyStatus, Action: func() error {
+			<-unblock
+			return nil
+		}},
+		{SrcStatus: ReadyStatus, DestStatus: StartedStatus, Action: noop},
+		{SrcStatus: ReadyStatus, DestStatus: DegradedStatus, Action: noop},
+		{SrcStatus: ReadyStatus, DestStatus: StoppedStatus, Action: noop},
+		{SrcStatus: StartedStatus, DestStatus: StoppedStatus, Action: noop},
+		{SrcStatus: DegradedStatus, DestStatus: StoppedStatus, Action: noop},
+	})
+	assert.NoError(t, err)
+
+	return basicActor, unblock
+}
+
+func TestUpdateStatus_PendingStatusQueue(t *testing.T) {
+	tm := NewSlashie()
+	basicActor, unblock := newBlockingActor(t, tm)
+
+	err := tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	// Both updates are queued while the actor is transitioning to READY.
+	err = tm.UpdateStatus(basicActor, StartedStatus)
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+
+	startedVisited := false
+	err = tm.Subscribe(basicActor, StartedStatus, func() {
+		startedVisited = true
+	})
+	assert.NoError(t, err)
+
+	close(unblock)
+	basicActor.Wait()
+
+	assert.True(t, startedVisited)
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+}
+
+func TestUpdateStatus_PendingStatusCoalesce(t *testing.T) {
+	tm := NewSlashie(WithPendingStatusPolicy(actor.PendingStatusCoalesce))
+	basicActor, unblock := newBlockingActor(t, tm)
+
+	err := tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	// Only the latest update is kept while the actor is transitioning to READY.
+	err = tm.UpdateStatus(basicActor, StartedStatus)
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, DegradedStatus)
+	assert.NoError(t, err)
+
+	ch := make(chan bool)
+	err = tm.Subscribe(basicActor, DegradedStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+
+	close(unblock)
+	<-ch
+
+	assert.Equal(t, DegradedStatus, tm.GetStatus(basicActor))
+	// STARTED was discarded, so the actor can still subscribe to it.
+	err = tm.Subscribe(basicActor, StartedStatus, func() {})
+	assert.NoError(t, err)
+}
+
+func TestUpdateStatus_PendingStatusReject(t *testing.T) {
+	tm := NewSlashie(WithPendingStatusPolicy(actor.PendingStatusReject))
+	basicActor, unblock := newBlockingActor(t, tm)
+
+	err := tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+
+	err = tm.UpdateStatus(basicActor, StartedStatus)
+	assert.ErrorIs(t, err, actor.ErrActorBusy)
+
+	ch := make(chan bool)
+	err = tm.Subscribe(basicActor, ReadyStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+
+	close(unblock)
+	<-ch
+
+	assert.Equal(t, ReadyStatus, tm.GetStatus(basicActor))
+}
+
+const (
+	RunningStatus   actor.Status = "RUNNING"
+	HealthyStatus   actor.Status = "RUNNING.HEALTHY"
+	UnhealthyStatus actor.Status = "RUNNING.UNHEALTHY"
+)
+
+func TestAddChildStatus(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+	depActor := NewBasicActor("Actor", "ActorB", tm)
+
+	err := tm.AddChildStatus(basicActor, RunningStatus, HealthyStatus)
+	assert.NoError(t, err)
+	err = tm.AddChildStatus(basicActor, RunningStatus, UnhealthyStatus)
+	assert.NoError(t, err)
+
+	noop := func() error { return nil }
+	err = tm.AddTransitionActions(basicActor, []*transition.TransitionAction{
+		{SrcStatus: NoneStatus, DestStatus: HealthyStatus, Action: noop},
+		{SrcStatus: HealthyStatus, DestStatus: UnhealthyStatus, Action: noop},
+		// Transitions defined on the parent apply to all children.
+		{SrcStatus: RunningStatus, DestStatus: StoppedStatus, Action: noop},
+	})
+	assert.NoError(t, err)
+	err = tm.AddTransitionAction(depActor, NoneStatus, ReadyStatus, noop)
+	assert.NoError(t, err)
+
+	// Events are only modified on the actor's goroutine.
+	var events []string
+	err = tm.OnEnter(basicActor, RunningStatus, func() {
+		events = append(events, "enter:running")
+	})
+	assert.NoError(t, err)
+	err = tm.OnExit(basicActor, RunningStatus, func() {
+		events = append(events, "exit:running")
+	})
+	assert.NoError(t, err)
+	err = tm.OnEnter(basicActor, UnhealthyStatus, func() {
+		events = append(events, "enter:unhealthy")
+	})
+	assert.NoError(t, err)
+	err = tm.Subscribe(basicActor, RunningStatus, func() {
+		events = append(events, "subscription:running")
+	})
+	assert.NoError(t, err)
+
+	// A dependency on the parent status is satisfied by any of its children.
+	err = tm.AddTransitionDependency(depActor, ReadyStatus, basicActor, RunningStatus)
+	assert.NoError(t, err)
+	ch := make(chan bool)
+	err = tm.Subscribe(depActor, ReadyStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(depActor, ReadyStatus)
+	assert.NoError(t, err)
+
+	err = tm.UpdateStatus(basicActor, HealthyStatus)
+	assert.NoError(t, err)
+	<-ch
+
+	err = tm.UpdateStatus(basicActor, UnhealthyStatus)
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+	basicActor.Wait()
+
+	assert.Equal(t, []string{"enter:running", "subscription:running", "enter:unhealthy", "exit:running"}, events)
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+}
+
+func TestAddChildStatus_InvalidHierarchy(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddChildStatus(basicActor, RunningStatus, HealthyStatus)
+	assert.NoError(t, err)
+
+	// A status cannot have more than one parent.
+	err = tm.AddChildStatus(basicActor, ReadyStatus, HealthyStatus)
+	assert.ErrorIs(t, err, actor.ErrInvalidStatusHierarchy)
+	// A status cannot be nested under one of its children.
+	err = tm.AddChildStatus(basicActor, HealthyStatus, RunningStatus)
+	assert.ErrorIs(t, err, actor.ErrInvalidStatusHierarchy)
+	err = tm.AddChildStatus(basicActor, ReadyStatus, ReadyStatus)
+	assert.ErrorIs(t, err, actor.ErrInvalidStatusHierarchy)
 }
@@ -0,0 +1,147 @@
+package slashie
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddTimedTransition(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	noop := func() error { return nil }
+	err := tm.AddTransitionAction(basicActor, NoneStatus, ReadyStatus, noop)
+	assert.NoError(t, err)
+	err = tm.AddTransitionAction(basicActor, ReadyStatus, StoppedStatus, noop)
+	assert.NoError(t, err)
+	err = tm.AddTimedTransition(basicActor, ReadyStatus, 30*time.Second, StoppedStatus)
+	assert.NoError(t, err)
+
+	ch := make(chan bool)
+	err = tm.Subscribe(basicActor, ReadyStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-ch
+	assert.Equal(t, ReadyStatus, tm.GetStatus(basicActor))
+
+	c.Advance(29 * time.Second)
+	assert.Equal(t, ReadyStatus, tm.GetStatus(basicActor))
+
+	c.Advance(time.Second)
+	basicActor.Wait()
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+}
+
+func TestAddTimedTransition_InitialStatus(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddTransitionAction(basicActor, NoneStatus, StoppedStatus, func() error { return nil })
+	assert.NoError(t, err)
+	// The actor is already in the status, so the timer starts immediately.
+	err = tm.AddTimedTransition(basicActor, NoneStatus, 5*time.Second, StoppedStatus)
+	assert.NoError(t, err)
+
+	c.Advance(5 * time.Second)
+	basicActor.Wait()
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+}
+
+func TestAddTimedTransition_LeaveStatus(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	noop := func() error { return nil }
+	err := tm.AddTransitionAction(basicActor, NoneStatus, ReadyStatus, noop)
+	assert.NoError(t, err)
+	err = tm.AddTransitionAction(basicActor, ReadyStatus, StartedStatus, noop)
+	assert.NoError(t, err)
+	err = tm.AddTransitionAction(basicActor, ReadyStatus, StoppedStatus, noop)
+	assert.NoError(t, err)
+	err = tm.AddTimedTransition(basicActor, ReadyStatus, 30*time.Second, StoppedStatus)
+	assert.NoError(t, err)
+
+	ch := make(chan bool)
+	err = tm.Subscribe(basicActor, StartedStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, StartedStatus)
+	assert.NoError(t, err)
+	<-ch
+	assert.Equal(t, StartedStatus, tm.GetStatus(basicActor))
+
+	// The timer was cancelled when the actor left READY.
+	assert.Equal(t, 0, c.NumTimers())
+	c.Advance(30 * time.Second)
+	assert.Equal(t, StartedStatus, tm.GetStatus(basicActor))
+}
+
+func TestAddTimedTransition_IllegalTransition(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.AddTimedTransition(basicActor, ReadyStatus, time.Second, NoneStatus)
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
+	err = tm.AddTimedTransition(basicActor, StoppedStatus, time.Second, ReadyStatus)
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
+	// There is no transition from READY to STOPPED.
+	err = tm.AddTimedTransition(basicActor, ReadyStatus, time.Second, StoppedStatus)
+	assert.ErrorIs(t, err, actor.ErrIllegalTransition)
+}
+
+func TestAddTimedTransition_WhileTransitioning(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	tm := NewSlashie(WithClock(c))
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	noop := func() error { return nil }
+	err := tm.AddTransitionAction(basicActor, NoneStatus, ReadyStatus, noop)
+	assert.NoError(t, err)
+	err = tm.AddTransitionAction(basicActor, ReadyStatus, StoppedStatus, noop)
+	assert.NoError(t, err)
+	started := make(chan bool)
+	err = tm.AddTransitionContextAction(basicActor, ReadyStatus, StartedStatus, func(ctx context.Context) error {
+		started <- true
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.NoError(t, err)
+	err = tm.AddTimedTransition(basicActor, ReadyStatus, 30*time.Second, StoppedStatus)
+	assert.NoError(t, err)
+
+	ch := make(chan bool)
+	err = tm.Subscribe(basicActor, ReadyStatus, func() {
+		ch <- true
+	})
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, ReadyStatus)
+	assert.NoError(t, err)
+	<-ch
+
+	// The timer fires while the actor is transitioning to STARTED.
+	err = tm.UpdateStatus(basicActor, StartedStatus)
+	assert.NoError(t, err)
+	<-started
+	c.Advance(30 * time.Second)
+	assert.Equal(t, ReadyStatus, tm.GetStatus(basicActor))
+
+	// Once the transition is cancelled, the actor is still in READY, so the timed transition applies.
+	err = tm.CancelTransition(basicActor, "test")
+	assert.NoError(t, err)
+	basicActor.Wait()
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+}
@@ -0,0 +1,184 @@
+package slashie
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/topic"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTopicActor creates an actor which passes the Envelope of each testMessage it receives to the returned channel.
+func newTopicActor(tm Slashie, actorId actor.Id) (actor.Actor, chan actor.Envelope) {
+	basicActor := NewBasicActor("Actor", actorId, tm)
+
+	envelopes := make(chan actor.Envelope, 10)
+	basicActor.RegisterMessageHandler(testMessageType, func(msg any) {
+		envelopes <- basicActor.CurrentEnvelope()
+	})
+
+	return basicActor, envelopes
+}
+
+func TestPublish(t *testing.T) {
+	tm := NewSlashie()
+	actorA, envelopesA := newTopicActor(tm, "ActorA")
+	actorB, envelopesB := newTopicActor(tm, "ActorB")
+
+	assert.NoError(t, tm.SubscribeTopic(actorA, "orders.created"))
+	assert.NoError(t, tm.SubscribeTopic(actorB, "orders.#"))
+
+	err := tm.Publish("orders.created", testMessage{message: "created"})
+	assert.NoError(t, err)
+	err = tm.Publish("orders.cancelled", testMessage{message: "cancelled"})
+	assert.NoError(t, err)
+
+	envelopeA := <-envelopesA
+	assert.Equal(t, testMessage{message: "created"}, envelopeA.Message)
+	assert.Equal(t, "orders.created", envelopeA.Header(TopicHeader))
+
+	// Each subscriber receives the same message.
+	envelopeB := <-envelopesB
+	assert.Equal(t, envelopeA.Id, envelopeB.Id)
+	envelopeB = <-envelopesB
+	assert.Equal(t, testMessage{message: "cancelled"}, envelopeB.Message)
+	assert.Equal(t, "orders.cancelled", envelopeB.Header(TopicHeader))
+
+	// Messages are delivered to each subscriber in order, so actor A would receive the cancelled message ahead of
+	// this one if it had been delivered.
+	err = tm.Publish("orders.created", testMessage{message: "sentinel"})
+	assert.NoError(t, err)
+	assert.Equal(t, testMessage{message: "sentinel"}, (<-envelopesA).Message)
+}
+
+func TestPublish_InvalidTopic(t *testing.T) {
+	tm := NewSlashie()
+
+	err := tm.Publish("orders.*", testMessage{message: "TestMessage"})
+	assert.ErrorIs(t, err, topic.ErrInvalidTopic)
+}
+
+func TestSubscribeTopic_Errors(t *testing.T) {
+	tm := NewSlashie()
+	basicActor := NewBasicActor("Actor", "ActorA", tm)
+
+	err := tm.SubscribeTopic(basicActor, "#.created")
+	assert.ErrorIs(t, err, topic.ErrInvalidPattern)
+
+	err = tm.SubscribeTopic(actor.NewBasicActor("Actor", "Unknown"), "orders.created")
+	assert.ErrorIs(t, err, actor.ErrUnknownActor)
+}
+
+func TestUnsubscribeTopic(t *testing.T) {
+	tm := NewSlashie()
+	basicActor, envelopes := newTopicActor(tm, "ActorA")
+
+	assert.NoError(t, tm.SubscribeTopic(basicActor, "orders.*"))
+	assert.True(t, tm.UnsubscribeTopic(basicActor, "orders.*"))
+	assert.False(t, tm.UnsubscribeTopic(basicActor, "orders.*"))
+
+	err := tm.Publish("orders.created", testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+
+	// Messages are delivered to each subscriber in order, so the actor would receive the first message ahead of this
+	// one if it had been delivered.
+	assert.NoError(t, tm.SubscribeTopic(basicActor, "sentinel"))
+	err = tm.Publish("sentinel", testMessage{message: "sentinel"})
+	assert.NoError(t, err)
+	assert.Equal(t, testMessage{message: "sentinel"}, (<-envelopes).Message)
+}
+
+func TestPublish_TerminalStatus(t *testing.T) {
+	tm := NewSlashie()
+	basicActor, _ := newTopicActor(tm, "ActorA")
+	deadLetters := subscribeDeadLetters(tm)
+	assert.NoError(t, tm.SubscribeTopic(basicActor, "orders.*"))
+
+	err := tm.AddTransitionAction(basicActor, NoneStatus, StoppedStatus, func() error { return nil })
+	assert.NoError(t, err)
+	err = tm.UpdateStatus(basicActor, StoppedStatus)
+	assert.NoError(t, err)
+	basicActor.Wait()
+	// Wait for the transition to complete.
+	assert.Equal(t, StoppedStatus, tm.GetStatus(basicActor))
+
+	// Subscriptions are removed once the actor reaches its terminal status.
+	err = tm.Publish("orders.created", testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+	assert.False(t, tm.UnsubscribeTopic(basicActor, "orders.*"))
+	assert.Empty(t, deadLetters)
+}
+
+func TestPublish_ActorStopped(t *testing.T) {
+	tm := NewSlashie()
+	basicActor, _ := newTopicActor(tm, "ActorA")
+	deadLetters := subscribeDeadLetters(tm)
+	assert.NoError(t, tm.SubscribeTopic(basicActor, "orders.*"))
+
+	basicActor.Stop()
+	basicActor.Wait()
+
+	// The message cannot be delivered, and the subscription is removed.
+	err := tm.Publish("orders.created", testMessage{message: "TestMessage"})
+	assert.NoError(t, err)
+	deadLetter := <-deadLetters
+	assert.ErrorIs(t, deadLetter.Reason, actor.ErrActorStopped)
+	assert.False(t, tm.UnsubscribeTopic(basicActor, "orders.*"))
+}
+
+func TestPublish_Subscribers(t *testing.T) {
+	tm := NewSlashie()
+	srcActor, srcEnvelopes := newTopicActor(tm, "Source")
+	fullActor := actor.NewBasicActor("Actor", "Full", actor.WithMailbox(actor.NewBoundedMailbox(1, actor.OverflowBlock)))
+	tm.AddActor(fullActor, NoneStatus, StoppedStatus)
+	destActor, destEnvelopes := newTopicActor(tm, "Destination")
+	deadLetters := subscribeDeadLetters(tm)
+
+	unblock := make(chan bool)
+	received := make(chan string, 3)
+	fullActor.RegisterMessageHandler(testMessageType, func(msg any) {
+		<-unblock
+		received <- msg.(testMessage).message
+	})
+	assert.NoError(t, tm.SubscribeTopic(fullActor, "orders.*"))
+	assert.NoError(t, tm.SubscribeTopic(destActor, "orders.*"))
+	// The first message blocks the actor, and the second fills its mailbox.
+	assert.NoError(t, fullActor.SendMessage(testMessage{message: "First"}))
+	assert.NoError(t, fullActor.TrySendMessageUntil(time.Now().Add(time.Second), testMessage{message: "Second"}))
+
+	// The source actor publishes to a topic which it subscribes to from its own goroutine.
+	assert.NoError(t, tm.SubscribeTopic(srcActor, "#"))
+	Handle(tm, srcActor, func(ctx ActorContext, message string) {
+		assert.NoError(t, ctx.Publish("orders.created", testMessage{message: message}))
+	})
+	assert.NoError(t, tm.SendMessage(srcActor.GetKey(), "TestMessage"))
+
+	assert.Equal(t, testMessage{message: "TestMessage"}, (<-srcEnvelopes).Message)
+	assert.Equal(t, testMessage{message: "TestMessage"}, (<-destEnvelopes).Message)
+
+	// The full mailbox holds up delivery to its actor, which receives the message once there is room for it.
+	close(unblock)
+	assert.Equal(t, "First", <-received)
+	assert.Equal(t, "Second", <-received)
+	assert.Equal(t, "TestMessage", <-received)
+	assert.Empty(t, deadLetters)
+}
+
+func TestActorContext_Publish(t *testing.T) {
+	tm := NewSlashie()
+	srcActor := NewBasicActor("Actor", "Source", tm)
+	destActor, envelopes := newTopicActor(tm, "Destination")
+	assert.NoError(t, tm.SubscribeTopic(destActor, "orders.created"))
+
+	Handle(tm, srcActor, func(ctx ActorContext, message string) {
+		assert.NoError(t, ctx.Publish("orders.created", testMessage{message: message}))
+	})
+	err := tm.SendMessage(srcActor.GetKey(), "TestMessage")
+	assert.NoError(t, err)
+
+	envelope := <-envelopes
+	assert.Equal(t, testMessage{message: "TestMessage"}, envelope.Message)
+	assert.Equal(t, srcActor.GetKey(), envelope.Sender)
+	assert.NotEmpty(t, envelope.CausationId)
+}
@@ -0,0 +1,44 @@
+package slashie
+
+import (
+	"github.com/strategicpause/slashie/actor"
+	"time"
+)
+
+// Snapshot is a point-in-time view of slashie and each of its actors. It holds no references to the actors themselves,
+// so that it can be serialized, for example as JSON.
+type Snapshot struct {
+	Timestamp time.Time `json:"timestamp"`
+	// MailboxLen is the number of messages waiting to be processed by slashie.
+	MailboxLen      int             `json:"mailboxLen"`
+	DeadLetterCount uint64          `json:"deadLetterCount"`
+	Actors          []ActorSnapshot `json:"actors"`
+}
+
+// ActorSnapshot is a point-in-time view of a single actor.
+type ActorSnapshot struct {
+	Key            actor.Key    `json:"key"`
+	Type           actor.Type   `json:"type"`
+	Id             actor.Id     `json:"id"`
+	InitialStatus  actor.Status `json:"initialStatus"`
+	TerminalStatus actor.Status `json:"terminalStatus"`
+	KnownStatus    actor.Status `json:"knownStatus"`
+	DesiredStatus  actor.Status `json:"desiredStatus"`
+	// Transition is the transition whose actions are running, or nil if there is none.
+	Transition *TransitionSnapshot `json:"transition,omitempty"`
+	// Dependencies are the actors, and their respective statuses, which the actor is waiting on before it can
+	// transition to each status.
+	Dependencies map[actor.Status]map[actor.Key]actor.Status `json:"dependencies,omitempty"`
+	// Subscriptions is the number of subscriptions waiting on the actor to transition to each status.
+	Subscriptions map[actor.Status]int `json:"subscriptions,omitempty"`
+	// MailboxLen is the number of messages waiting in the actor's mailbox, or 0 if the actor does not report it.
+	MailboxLen int `json:"mailboxLen"`
+}
+
+// TransitionSnapshot is a point-in-time view of an in-flight transition.
+type TransitionSnapshot struct {
+	SrcStatus  actor.Status `json:"srcStatus"`
+	DestStatus actor.Status `json:"destStatus"`
+	// OutstandingActions is the number of actions which have not yet completed.
+	OutstandingActions int `json:"outstandingActions"`
+}
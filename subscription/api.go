@@ -6,4 +6,7 @@ type Manager interface {
 	// Subscribe adds a callback when the given actor transitions to the given status.
 	Subscribe(actorKey actor.Key, status actor.Status, callback Subscription)
 	HandleSubscriptionsForStatus(actorKey actor.Key, status actor.Status, callback func(s Subscription))
+	// GetSubscriptionCounts returns the number of subscriptions which are waiting on the given actor to transition to
+	// each status.
+	GetSubscriptionCounts(actorKey actor.Key) map[actor.Status]int
 }
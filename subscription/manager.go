@@ -32,3 +32,13 @@ func (m *manager) HandleSubscriptionsForStatus(actorKey actor.Key, status actor.
 	}
 	delete(m.subscriptionsForActor[actorKey], status)
 }
+
+func (m *manager) GetSubscriptionCounts(actorKey actor.Key) map[actor.Status]int {
+	counts := map[actor.Status]int{}
+	for status, subscriptions := range m.subscriptionsForActor[actorKey] {
+		if len(subscriptions) > 0 {
+			counts[status] = len(subscriptions)
+		}
+	}
+	return counts
+}
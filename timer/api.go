@@ -0,0 +1,31 @@
+package timer
+
+import (
+	"github.com/strategicpause/slashie/actor"
+	"time"
+)
+
+// Manager keeps track of timed transitions, and the timers which are running for each actor.
+type Manager interface {
+	// AddTimedTransition will move the given actor to destStatus once it has been in the given status for the
+	// given duration.
+	AddTimedTransition(actorKey actor.Key, status actor.Status, after time.Duration, destStatus actor.Status)
+	// StartTimers starts a timer for each timed transition of the given actor & status. When a timer fires, the
+	// callback is called from the clock's goroutine with the destination status and the generation of the timers,
+	// which can be passed to IsActive to determine whether the timer is still relevant.
+	StartTimers(actorKey actor.Key, status actor.Status, callback func(destStatus actor.Status, generation uint64))
+	// StopTimers stops all timers for the given actor & status.
+	StopTimers(actorKey actor.Key, status actor.Status)
+	// StopAllTimers stops all timers for the given actor.
+	StopAllTimers(actorKey actor.Key)
+	// IsActive returns true if the timers for the given actor & status with the given generation have not been
+	// stopped.
+	IsActive(actorKey actor.Key, status actor.Status, generation uint64) bool
+	// DeferTimedTransition records that a timer for the given actor & status fired while the actor was transitioning,
+	// so that the timed transition can be applied once the transition completes. It is discarded if the timers with
+	// the given generation are stopped first.
+	DeferTimedTransition(actorKey actor.Key, status actor.Status, generation uint64, destStatus actor.Status)
+	// TakeDeferredTransitions removes and returns the deferred timed transitions for the given actor, in the order in
+	// which their timers fired.
+	TakeDeferredTransitions(actorKey actor.Key) []DeferredTransition
+}
@@ -0,0 +1,108 @@
+package timer
+
+import (
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/clock"
+	"time"
+)
+
+type manager struct {
+	clock clock.Clock
+	// timedTransitionsByActor
+	timedTransitionsByActor map[actor.Key]TimedTransitionsByStatus
+	// activeTimersByActor tracks the timers which are running for an actor in a given status.
+	activeTimersByActor map[actor.Key]map[actor.Status]*activeTimers
+	// deferredByActor tracks the timed transitions which fired while an actor was transitioning.
+	deferredByActor map[actor.Key][]deferredTransition
+	// nextGeneration is used to assign each set of timers a unique generation.
+	nextGeneration uint64
+}
+
+func NewManager(c clock.Clock) Manager {
+	return &manager{
+		clock:                   c,
+		timedTransitionsByActor: map[actor.Key]TimedTransitionsByStatus{},
+		activeTimersByActor:     map[actor.Key]map[actor.Status]*activeTimers{},
+		deferredByActor:         map[actor.Key][]deferredTransition{},
+	}
+}
+
+func (m *manager) AddTimedTransition(actorKey actor.Key, status actor.Status, after time.Duration, destStatus actor.Status) {
+	if _, ok := m.timedTransitionsByActor[actorKey]; !ok {
+		m.timedTransitionsByActor[actorKey] = TimedTransitionsByStatus{}
+	}
+	timedTransitions := m.timedTransitionsByActor[actorKey]
+
+	timedTransitions[status] = append(timedTransitions[status], &TimedTransition{
+		After:      after,
+		DestStatus: destStatus,
+	})
+}
+
+func (m *manager) StartTimers(actorKey actor.Key, status actor.Status, callback func(destStatus actor.Status, generation uint64)) {
+	timedTransitions := m.timedTransitionsByActor[actorKey][status]
+	if len(timedTransitions) == 0 {
+		return
+	}
+	m.StopTimers(actorKey, status)
+
+	m.nextGeneration += 1
+	active := &activeTimers{generation: m.nextGeneration}
+	for _, timedTransition := range timedTransitions {
+		destStatus, generation := timedTransition.DestStatus, active.generation
+		active.timers = append(active.timers, m.clock.AfterFunc(timedTransition.After, func() {
+			callback(destStatus, generation)
+		}))
+	}
+
+	if _, ok := m.activeTimersByActor[actorKey]; !ok {
+		m.activeTimersByActor[actorKey] = map[actor.Status]*activeTimers{}
+	}
+	m.activeTimersByActor[actorKey][status] = active
+}
+
+func (m *manager) StopTimers(actorKey actor.Key, status actor.Status) {
+	active, ok := m.activeTimersByActor[actorKey][status]
+	if !ok {
+		return
+	}
+	for _, t := range active.timers {
+		t.Stop()
+	}
+	delete(m.activeTimersByActor[actorKey], status)
+}
+
+func (m *manager) StopAllTimers(actorKey actor.Key) {
+	for status := range m.activeTimersByActor[actorKey] {
+		m.StopTimers(actorKey, status)
+	}
+	delete(m.activeTimersByActor, actorKey)
+	delete(m.deferredByActor, actorKey)
+}
+
+func (m *manager) IsActive(actorKey actor.Key, status actor.Status, generation uint64) bool {
+	active, ok := m.activeTimersByActor[actorKey][status]
+	return ok && active.generation == generation
+}
+
+func (m *manager) DeferTimedTransition(actorKey actor.Key, status actor.Status, generation uint64, destStatus actor.Status) {
+	if !m.IsActive(actorKey, status, generation) {
+		return
+	}
+	m.deferredByActor[actorKey] = append(m.deferredByActor[actorKey], deferredTransition{
+		DeferredTransition: DeferredTransition{Status: status, DestStatus: destStatus},
+		generation:         generation,
+	})
+}
+
+func (m *manager) TakeDeferredTransitions(actorKey actor.Key) []DeferredTransition {
+	var transitions []DeferredTransition
+	for _, deferred := range m.deferredByActor[actorKey] {
+		// Timers which have since been stopped no longer apply.
+		if m.IsActive(actorKey, deferred.Status, deferred.generation) {
+			transitions = append(transitions, deferred.DeferredTransition)
+		}
+	}
+	delete(m.deferredByActor, actorKey)
+	return transitions
+}
@@ -0,0 +1,92 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ActorKey = "ActorKey"
+
+	SrcStatus     = "SrcStatus"
+	DestStatus    = "DestStatus"
+	MissingStatus = "MissingStatus"
+)
+
+func TestStartTimers(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	mgr := NewManager(c)
+	mgr.AddTimedTransition(ActorKey, SrcStatus, time.Second, DestStatus)
+
+	var fired []actor.Status
+	var generation uint64
+	mgr.StartTimers(ActorKey, SrcStatus, func(destStatus actor.Status, g uint64) {
+		fired = append(fired, destStatus)
+		generation = g
+	})
+	// No timers are started for a status without timed transitions.
+	mgr.StartTimers(ActorKey, MissingStatus, func(actor.Status, uint64) {
+		assert.Fail(t, "timer should not fire")
+	})
+	assert.Equal(t, 1, c.NumTimers())
+
+	c.Advance(time.Second)
+	assert.Equal(t, []actor.Status{DestStatus}, fired)
+	assert.True(t, mgr.IsActive(ActorKey, SrcStatus, generation))
+
+	// Restarting the timers invalidates the previous generation.
+	mgr.StartTimers(ActorKey, SrcStatus, func(actor.Status, uint64) {})
+	assert.False(t, mgr.IsActive(ActorKey, SrcStatus, generation))
+}
+
+func TestStopTimers(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	mgr := NewManager(c)
+	mgr.AddTimedTransition(ActorKey, SrcStatus, time.Second, DestStatus)
+	mgr.AddTimedTransition(ActorKey, DestStatus, time.Second, SrcStatus)
+
+	var generation uint64
+	mgr.StartTimers(ActorKey, SrcStatus, func(_ actor.Status, g uint64) {
+		assert.Fail(t, "timer should not fire")
+	})
+	mgr.StartTimers(ActorKey, DestStatus, func(_ actor.Status, g uint64) {
+		assert.Fail(t, "timer should not fire")
+	})
+	assert.Equal(t, 2, c.NumTimers())
+
+	mgr.StopTimers(ActorKey, SrcStatus)
+	assert.Equal(t, 1, c.NumTimers())
+	assert.False(t, mgr.IsActive(ActorKey, SrcStatus, generation))
+
+	mgr.StopAllTimers(ActorKey)
+	assert.Equal(t, 0, c.NumTimers())
+
+	c.Advance(time.Second)
+}
+
+func TestDeferTimedTransition(t *testing.T) {
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	mgr := NewManager(c)
+	mgr.AddTimedTransition(ActorKey, SrcStatus, time.Second, DestStatus)
+
+	var generation uint64
+	mgr.StartTimers(ActorKey, SrcStatus, func(destStatus actor.Status, g uint64) {
+		generation = g
+	})
+	c.Advance(time.Second)
+
+	mgr.DeferTimedTransition(ActorKey, SrcStatus, generation, DestStatus)
+	assert.Equal(t, []DeferredTransition{{Status: SrcStatus, DestStatus: DestStatus}}, mgr.TakeDeferredTransitions(ActorKey))
+	assert.Empty(t, mgr.TakeDeferredTransitions(ActorKey))
+
+	// Deferred transitions are discarded once their timers are stopped.
+	mgr.DeferTimedTransition(ActorKey, SrcStatus, generation, DestStatus)
+	mgr.StopTimers(ActorKey, SrcStatus)
+	assert.Empty(t, mgr.TakeDeferredTransitions(ActorKey))
+	mgr.DeferTimedTransition(ActorKey, SrcStatus, generation, DestStatus)
+	assert.Empty(t, mgr.TakeDeferredTransitions(ActorKey))
+}
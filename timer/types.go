@@ -0,0 +1,35 @@
+package timer
+
+import (
+	"github.com/strategicpause/slashie/actor"
+	"github.com/strategicpause/slashie/clock"
+	"time"
+)
+
+// TimedTransition moves an actor to DestStatus once it has been in a status for the given duration.
+type TimedTransition struct {
+	After      time.Duration
+	DestStatus actor.Status
+}
+
+// TimedTransitionsByStatus
+type TimedTransitionsByStatus map[actor.Status][]*TimedTransition
+
+// DeferredTransition is a timed transition whose timer fired while the actor was transitioning.
+type DeferredTransition struct {
+	Status     actor.Status
+	DestStatus actor.Status
+}
+
+// activeTimers are the timers which have been started for an actor entering a given status.
+type activeTimers struct {
+	// generation distinguishes timers from previous visits to the same status.
+	generation uint64
+	timers     []clock.Timer
+}
+
+// deferredTransition is a DeferredTransition along with the generation of the timer which fired.
+type deferredTransition struct {
+	DeferredTransition
+	generation uint64
+}
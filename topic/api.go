@@ -0,0 +1,18 @@
+package topic
+
+import "github.com/strategicpause/slashie/actor"
+
+// Manager keeps track of the topics which each actor subscribes to.
+type Manager interface {
+	// Subscribe subscribes the given actor to each topic which matches the given pattern. An InvalidPatternError is
+	// returned if the pattern is malformed. Subscribing to the same pattern more than once has no effect.
+	Subscribe(actorKey actor.Key, pattern string) error
+	// Unsubscribe removes the subscription of the given actor to the given pattern. Returns false if the actor was not
+	// subscribed to the pattern.
+	Unsubscribe(actorKey actor.Key, pattern string) bool
+	// UnsubscribeAll removes all subscriptions for the given actor.
+	UnsubscribeAll(actorKey actor.Key)
+	// Subscribers returns each actor which is subscribed to a pattern matching the given topic, in the order in which
+	// they first subscribed. An InvalidTopicError is returned if the topic contains wildcards or is malformed.
+	Subscribers(topic string) ([]actor.Key, error)
+}
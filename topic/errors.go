@@ -0,0 +1,39 @@
+package topic
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidPattern is returned when subscribing to a malformed pattern.
+	ErrInvalidPattern = errors.New("invalid pattern")
+	// ErrInvalidTopic is returned when publishing to a malformed topic.
+	ErrInvalidTopic = errors.New("invalid topic")
+)
+
+// InvalidPatternError indicates that Pattern cannot be subscribed to.
+type InvalidPatternError struct {
+	Pattern string
+}
+
+func (e *InvalidPatternError) Error() string {
+	return fmt.Sprintf("invalid pattern %q: segments must be non-empty, and %s may only be the last segment", e.Pattern, MultiWildcard)
+}
+
+func (e *InvalidPatternError) Is(target error) bool {
+	return target == ErrInvalidPattern
+}
+
+// InvalidTopicError indicates that messages cannot be published to Topic.
+type InvalidTopicError struct {
+	Topic string
+}
+
+func (e *InvalidTopicError) Error() string {
+	return fmt.Sprintf("invalid topic %q: segments must be non-empty, and must not contain wildcards", e.Topic)
+}
+
+func (e *InvalidTopicError) Is(target error) bool {
+	return target == ErrInvalidTopic
+}
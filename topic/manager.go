@@ -0,0 +1,71 @@
+package topic
+
+import "github.com/strategicpause/slashie/actor"
+
+type manager struct {
+	// subscriptions are ordered by the time at which they were added.
+	subscriptions []*subscription
+}
+
+func NewManager() Manager {
+	return &manager{}
+}
+
+func (m *manager) Subscribe(actorKey actor.Key, p string) error {
+	segments, ok := parsePattern(p)
+	if !ok {
+		return &InvalidPatternError{Pattern: p}
+	}
+	if m.indexOf(actorKey, p) >= 0 {
+		return nil
+	}
+	m.subscriptions = append(m.subscriptions, &subscription{actorKey: actorKey, pattern: p, segments: segments})
+	return nil
+}
+
+func (m *manager) Unsubscribe(actorKey actor.Key, p string) bool {
+	i := m.indexOf(actorKey, p)
+	if i < 0 {
+		return false
+	}
+	m.subscriptions = append(m.subscriptions[:i], m.subscriptions[i+1:]...)
+	return true
+}
+
+func (m *manager) UnsubscribeAll(actorKey actor.Key) {
+	subscriptions := m.subscriptions[:0]
+	for _, s := range m.subscriptions {
+		if s.actorKey != actorKey {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	m.subscriptions = subscriptions
+}
+
+func (m *manager) Subscribers(topic string) ([]actor.Key, error) {
+	segments, ok := parseTopic(topic)
+	if !ok {
+		return nil, &InvalidTopicError{Topic: topic}
+	}
+
+	var subscribers []actor.Key
+	// An actor which subscribes to several matching patterns only receives each message once.
+	seen := map[actor.Key]bool{}
+	for _, s := range m.subscriptions {
+		if !seen[s.actorKey] && s.segments.matches(segments) {
+			seen[s.actorKey] = true
+			subscribers = append(subscribers, s.actorKey)
+		}
+	}
+	return subscribers, nil
+}
+
+// indexOf returns the index of the subscription for the given actor & pattern, or -1 if there is none.
+func (m *manager) indexOf(actorKey actor.Key, p string) int {
+	for i, s := range m.subscriptions {
+		if s.actorKey == actorKey && s.pattern == p {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,121 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/strategicpause/slashie/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ActorA = "Actor:ActorA"
+	ActorB = "Actor:ActorB"
+)
+
+func TestSubscribers(t *testing.T) {
+	mgr := NewManager()
+	assert.NoError(t, mgr.Subscribe(ActorA, "orders.created"))
+	assert.NoError(t, mgr.Subscribe(ActorB, "orders.*"))
+
+	subscribers, err := mgr.Subscribers("orders.created")
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Key{ActorA, ActorB}, subscribers)
+
+	subscribers, err = mgr.Subscribers("orders.cancelled")
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Key{ActorB}, subscribers)
+
+	subscribers, err = mgr.Subscribers("payments.created")
+	assert.NoError(t, err)
+	assert.Empty(t, subscribers)
+}
+
+func TestSubscribers_Wildcards(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		matches bool
+	}{
+		{"orders", "orders", true},
+		{"orders", "orders.created", false},
+		{"orders.*", "orders", false},
+		{"orders.*", "orders.created", true},
+		{"orders.*", "orders.created.eu", false},
+		{"*.created", "orders.created", true},
+		{"orders.#", "orders", true},
+		{"orders.#", "orders.created.eu", true},
+		{"orders.*.#", "orders", false},
+		{"orders.*.#", "orders.created", true},
+		{"#", "orders.created", true},
+	}
+	for _, test := range tests {
+		mgr := NewManager()
+		assert.NoError(t, mgr.Subscribe(ActorA, test.pattern))
+
+		subscribers, err := mgr.Subscribers(test.topic)
+		assert.NoError(t, err)
+		assert.Equal(t, test.matches, len(subscribers) == 1, "%s should match %s: %t", test.pattern, test.topic, test.matches)
+	}
+}
+
+func TestSubscribers_MultipleMatchingPatterns(t *testing.T) {
+	mgr := NewManager()
+	assert.NoError(t, mgr.Subscribe(ActorA, "orders.*"))
+	assert.NoError(t, mgr.Subscribe(ActorA, "orders.#"))
+	// Subscribing to the same pattern more than once has no effect.
+	assert.NoError(t, mgr.Subscribe(ActorA, "orders.*"))
+
+	subscribers, err := mgr.Subscribers("orders.created")
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Key{ActorA}, subscribers)
+}
+
+func TestSubscribe_InvalidPattern(t *testing.T) {
+	mgr := NewManager()
+
+	for _, pattern := range []string{"", "orders.", "orders..created", "#.created"} {
+		err := mgr.Subscribe(ActorA, pattern)
+		var patternErr *InvalidPatternError
+		assert.ErrorAs(t, err, &patternErr)
+		assert.ErrorIs(t, err, ErrInvalidPattern)
+		assert.Equal(t, pattern, patternErr.Pattern)
+	}
+}
+
+func TestSubscribers_InvalidTopic(t *testing.T) {
+	mgr := NewManager()
+
+	for _, topic := range []string{"", "orders.", "orders.*", "orders.#"} {
+		_, err := mgr.Subscribers(topic)
+		assert.ErrorIs(t, err, ErrInvalidTopic)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	mgr := NewManager()
+	assert.NoError(t, mgr.Subscribe(ActorA, "orders.*"))
+	assert.NoError(t, mgr.Subscribe(ActorB, "orders.*"))
+
+	assert.True(t, mgr.Unsubscribe(ActorA, "orders.*"))
+	assert.False(t, mgr.Unsubscribe(ActorA, "orders.*"))
+
+	subscribers, err := mgr.Subscribers("orders.created")
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Key{ActorB}, subscribers)
+}
+
+func TestUnsubscribeAll(t *testing.T) {
+	mgr := NewManager()
+	assert.NoError(t, mgr.Subscribe(ActorA, "orders.*"))
+	assert.NoError(t, mgr.Subscribe(ActorA, "payments.*"))
+	assert.NoError(t, mgr.Subscribe(ActorB, "orders.*"))
+
+	mgr.UnsubscribeAll(ActorA)
+
+	subscribers, err := mgr.Subscribers("orders.created")
+	assert.NoError(t, err)
+	assert.Equal(t, []actor.Key{ActorB}, subscribers)
+	subscribers, err = mgr.Subscribers("payments.created")
+	assert.NoError(t, err)
+	assert.Empty(t, subscribers)
+}
@@ -0,0 +1,68 @@
+package topic
+
+import (
+	"github.com/strategicpause/slashie/actor"
+	"strings"
+)
+
+const (
+	// Separator separates the segments of a topic, such as "orders.created".
+	Separator = "."
+	// SingleWildcard matches exactly one segment of a topic. For example, "orders.*" matches "orders.created", but not
+	// "orders" or "orders.created.eu".
+	SingleWildcard = "*"
+	// MultiWildcard matches zero or more trailing segments of a topic, and must be the last segment of a pattern. For
+	// example, "orders.#" matches "orders", "orders.created" and "orders.created.eu".
+	MultiWildcard = "#"
+)
+
+// pattern is a parsed subscription pattern.
+type pattern []string
+
+// subscription is a pattern which an actor has subscribed to.
+type subscription struct {
+	actorKey actor.Key
+	pattern  string
+	segments pattern
+}
+
+// parsePattern splits the given pattern into segments, returning false if it is malformed.
+func parsePattern(p string) (pattern, bool) {
+	segments := strings.Split(p, Separator)
+	for i, segment := range segments {
+		if segment == "" {
+			return nil, false
+		}
+		if segment == MultiWildcard && i != len(segments)-1 {
+			return nil, false
+		}
+	}
+	return segments, true
+}
+
+// parseTopic splits the given topic into segments, returning false if it is malformed or contains wildcards.
+func parseTopic(topic string) ([]string, bool) {
+	segments := strings.Split(topic, Separator)
+	for _, segment := range segments {
+		if segment == "" || segment == SingleWildcard || segment == MultiWildcard {
+			return nil, false
+		}
+	}
+	return segments, true
+}
+
+// matches returns true if the pattern matches the given topic segments.
+func (p pattern) matches(topic []string) bool {
+	for i, segment := range p {
+		if segment == MultiWildcard {
+			return true
+		}
+		if i >= len(topic) {
+			return false
+		}
+		if segment != SingleWildcard && segment != topic[i] {
+			return false
+		}
+	}
+	return len(p) == len(topic)
+}
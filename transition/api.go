@@ -8,15 +8,43 @@ import (
 type Manager interface {
 	// AddTransitionAction adds a callback function to execute to determine if the given actor can transition from
 	// the srcStatus to the destStatus. If the callback fails returns an error, then the transition will not occur.
+	// If srcStatus is AnyStatus, then this is equivalent to calling AddWildcardTransitionAction with no exclusions.
 	AddTransitionAction(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status, callback Action)
+	// AddTransitionContextAction is the same as AddTransitionAction, except that the callback receives a context
+	// which is cancelled if the transition is cancelled.
+	AddTransitionContextAction(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status, callback ContextAction)
+	// AddWildcardTransitionAction adds a callback function to execute when the given actor transitions from any
+	// status, other than the excludedStatuses, to the destStatus. Wildcard actions are only used when no action has
+	// been added for the specific source status.
+	AddWildcardTransitionAction(actorKey actor.Key, excludedStatuses []actor.Status, destStatus actor.Status, callback Action)
 	// IsValidTransition returns true if the given actor is configured to transition from the srcStatus to the
 	// depStatus. This is indicated by whether or not a transaction action has been added for the given source &
 	// destination status.
 	IsValidTransition(actorKey actor.Key, srcStatus actor.Status, depStatus actor.Status) bool
+	// FindPath returns the shortest sequence of statuses which the given actor can transition through to get from the
+	// srcStatus to the destStatus. The path excludes srcStatus and ends with destStatus. The second return value is
+	// false if the destStatus is not reachable. Intermediate statuses for which canTransitionFrom returns false will
+	// not be included in the path.
+	FindPath(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status, canTransitionFrom func(actor.Status) bool) ([]actor.Status, bool)
 	// StartTransition will manage the actions to transition the given actor from the currentStatus to the
-	// desiredStatus. Each action will be provided as a parameter to the given function.
-	StartTransition(actorKey actor.Key, currentStatus actor.Status, desiredStatus actor.Status, f func(a Action))
-	// CompleteTransitionAction is called when an Action has completed running. The results of all actions will be
-	// sent to the given resultFunc to determine what steps to take next.
-	CompleteTransitionAction(actorKey actor.Key, result error, resultFunc func(results chan error))
+	// desiredStatus. Each action will be provided as a parameter to the given function, along with the id of the
+	// transition, which must be passed to CompleteTransitionAction once the action has completed.
+	StartTransition(actorKey actor.Key, currentStatus actor.Status, desiredStatus actor.Status, f func(transitionId uint64, a Action))
+	// AddTransitionGuard adds a Guard which must be satisfied before the given actor can begin transitioning from the
+	// srcStatus to the destStatus.
+	AddTransitionGuard(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status, guard Guard)
+	// GetTransitionGuards returns all Guards which must be satisfied before the given actor can begin transitioning
+	// from the srcStatus to the destStatus.
+	GetTransitionGuards(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status) []Guard
+	// CompleteTransitionAction is called when an Action for the given transition has completed running. The results of
+	// all actions will be sent to the given resultFunc to determine what steps to take next. Results from a cancelled
+	// transition are discarded.
+	CompleteTransitionAction(actorKey actor.Key, transitionId uint64, result error, resultFunc func(results chan error))
+	// CancelTransition cancels the in-flight transition for the given actor. The context given to any running actions
+	// is cancelled, and the results of those actions will be discarded. Returns false if the actor has no in-flight
+	// transition.
+	CancelTransition(actorKey actor.Key, reason string) bool
+	// GetInFlightTransition returns the in-flight transition for the given actor. The second return value is false if
+	// the actor has no in-flight transition.
+	GetInFlightTransition(actorKey actor.Key) (InFlightTransition, bool)
 }
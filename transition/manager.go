@@ -1,77 +1,268 @@
 package transition
 
 import (
+	"context"
 	"github.com/strategicpause/slashie/actor"
+	"sort"
 )
 
 type manager struct {
 
 	// transitionActionsByActor
 	transitionActionsByActor map[actor.Key]ActionsByStatus
-	transitionsByActorChan   map[actor.Key]chan error
+	// wildcardActionsByActor
+	wildcardActionsByActor map[actor.Key]WildcardActionsByStatus
+	// transitionsByActor tracks the in-flight transition for each actor.
+	transitionsByActor map[actor.Key]*transitionState
+	// transitionGuardsByActor
+	transitionGuardsByActor map[actor.Key]GuardsByStatus
+	// nextTransitionId is used to assign each transition a unique id.
+	nextTransitionId uint64
+	// lineage resolves the ancestors of a status, so that transitions defined on a parent apply to its children.
+	lineage LineageFunc
 }
 
-func NewManager() Manager {
-	return &manager{
+type Opt func(t *manager)
+
+// WithLineageFunc sets the function used to resolve the ancestors of a status. Transitions registered for a parent
+// status apply to all of its children, unless a transition is registered for the child status itself.
+func WithLineageFunc(f LineageFunc) Opt {
+	return func(t *manager) {
+		t.lineage = f
+	}
+}
+
+func NewManager(opts ...Opt) Manager {
+	t := &manager{
 		transitionActionsByActor: map[actor.Key]ActionsByStatus{},
-		transitionsByActorChan:   map[actor.Key]chan error{},
+		wildcardActionsByActor:   map[actor.Key]WildcardActionsByStatus{},
+		transitionsByActor:       map[actor.Key]*transitionState{},
+		transitionGuardsByActor:  map[actor.Key]GuardsByStatus{},
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if t.lineage == nil {
+		t.lineage = func(_ actor.Key, status actor.Status) []actor.Status {
+			return []actor.Status{status}
+		}
 	}
+
+	return t
 }
 
 func (t *manager) AddTransitionAction(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status, callback Action) {
+	t.AddTransitionContextAction(actorKey, srcStatus, destStatus, withContext(callback))
+}
+
+func (t *manager) AddTransitionContextAction(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status, callback ContextAction) {
+	if srcStatus == AnyStatus {
+		t.addWildcardTransitionAction(actorKey, nil, destStatus, callback)
+		return
+	}
 	if _, ok := t.transitionActionsByActor[actorKey]; !ok {
 		t.transitionActionsByActor[actorKey] = ActionsByStatus{}
 	}
 	transitionCallbacks := t.transitionActionsByActor[actorKey]
 	if _, ok := transitionCallbacks[srcStatus]; !ok {
-		transitionCallbacks[srcStatus] = map[actor.Status][]Action{}
+		transitionCallbacks[srcStatus] = map[actor.Status][]ContextAction{}
 	}
 	transitionCallbacks[srcStatus][destStatus] = append(transitionCallbacks[srcStatus][destStatus], callback)
 }
 
-func (t *manager) IsValidTransition(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status) bool {
-	if _, ok := t.transitionActionsByActor[actorKey]; !ok {
-		return false
+func (t *manager) AddTransitionGuard(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status, guard Guard) {
+	if _, ok := t.transitionGuardsByActor[actorKey]; !ok {
+		t.transitionGuardsByActor[actorKey] = GuardsByStatus{}
 	}
-	transitionCallbacks := t.transitionActionsByActor[actorKey]
-	if _, ok := transitionCallbacks[srcStatus]; !ok {
-		return false
+	guardsByStatus := t.transitionGuardsByActor[actorKey]
+	if _, ok := guardsByStatus[srcStatus]; !ok {
+		guardsByStatus[srcStatus] = map[actor.Status][]Guard{}
 	}
+	guardsByStatus[srcStatus][destStatus] = append(guardsByStatus[srcStatus][destStatus], guard)
+}
+
+func (t *manager) GetTransitionGuards(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status) []Guard {
+	var guards []Guard
+	for _, status := range t.lineage(actorKey, srcStatus) {
+		guards = append(guards, t.transitionGuardsByActor[actorKey][status][destStatus]...)
+	}
+	return guards
+}
 
-	_, ok := t.transitionActionsByActor[actorKey][srcStatus][destStatus]
+func (t *manager) AddWildcardTransitionAction(actorKey actor.Key, excludedStatuses []actor.Status, destStatus actor.Status, callback Action) {
+	t.addWildcardTransitionAction(actorKey, excludedStatuses, destStatus, withContext(callback))
+}
+
+func (t *manager) addWildcardTransitionAction(actorKey actor.Key, excludedStatuses []actor.Status, destStatus actor.Status, callback ContextAction) {
+	if _, ok := t.wildcardActionsByActor[actorKey]; !ok {
+		t.wildcardActionsByActor[actorKey] = WildcardActionsByStatus{}
+	}
+	excluded := map[actor.Status]struct{}{}
+	for _, status := range excludedStatuses {
+		excluded[status] = struct{}{}
+	}
+	wildcardActions := t.wildcardActionsByActor[actorKey]
+	wildcardActions[destStatus] = append(wildcardActions[destStatus], &WildcardActions{
+		ExcludedStatuses: excluded,
+		Actions:          []ContextAction{callback},
+	})
+}
+
+func (t *manager) IsValidTransition(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status) bool {
+	_, ok := t.resolveActions(actorKey, srcStatus, destStatus)
 	return ok
 }
 
-func (t *manager) StartTransition(actorKey actor.Key, currentStatus actor.Status, desiredStatus actor.Status, f func(a Action)) {
-	if _, ok := t.transitionActionsByActor[actorKey]; !ok {
-		return
+// FindPath performs a BFS over the transitions registered for the given actor, including wildcard transitions, to
+// find the shortest path from srcStatus to destStatus.
+func (t *manager) FindPath(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status, canTransitionFrom func(actor.Status) bool) ([]actor.Status, bool) {
+	// previous is used to reconstruct the path once destStatus has been reached.
+	previous := map[actor.Status]actor.Status{srcStatus: srcStatus}
+	queue := []actor.Status{srcStatus}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		if curr == destStatus {
+			var path []actor.Status
+			for status := curr; status != srcStatus; status = previous[status] {
+				path = append([]actor.Status{status}, path...)
+			}
+			return path, len(path) > 0
+		}
+		if curr != srcStatus && !canTransitionFrom(curr) {
+			continue
+		}
+		for _, next := range t.nextStatuses(actorKey, curr) {
+			if _, ok := previous[next]; ok {
+				continue
+			}
+			previous[next] = curr
+			queue = append(queue, next)
+		}
 	}
+	return nil, false
+}
 
-	transitionCallbacks := t.transitionActionsByActor[actorKey]
-	if _, ok := transitionCallbacks[currentStatus]; !ok {
+// nextStatuses returns all statuses which the given actor can directly transition to from the given status. Statuses
+// are sorted so that paths are found deterministically.
+func (t *manager) nextStatuses(actorKey actor.Key, status actor.Status) []actor.Status {
+	candidates := map[actor.Status]struct{}{}
+	for _, src := range t.lineage(actorKey, status) {
+		for destStatus := range t.transitionActionsByActor[actorKey][src] {
+			candidates[destStatus] = struct{}{}
+		}
+	}
+	for destStatus := range t.wildcardActionsByActor[actorKey] {
+		candidates[destStatus] = struct{}{}
+	}
+
+	var statuses []actor.Status
+	for destStatus := range candidates {
+		if _, ok := t.resolveActions(actorKey, status, destStatus); ok {
+			statuses = append(statuses, destStatus)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i] < statuses[j]
+	})
+	return statuses
+}
+
+func (t *manager) StartTransition(actorKey actor.Key, currentStatus actor.Status, desiredStatus actor.Status, f func(transitionId uint64, a Action)) {
+	actions, ok := t.resolveActions(actorKey, currentStatus, desiredStatus)
+	if !ok {
 		return
 	}
 
-	actions := transitionCallbacks[currentStatus][desiredStatus]
-	numActions := len(actions)
-	t.transitionsByActorChan[actorKey] = make(chan error, numActions)
+	t.nextTransitionId += 1
+	ctx, cancel := context.WithCancel(context.Background())
+	reason := ""
+	state := &transitionState{
+		srcStatus:  currentStatus,
+		destStatus: desiredStatus,
+		id:         t.nextTransitionId,
+		results:    make(chan error, len(actions)),
+		cancel:     cancel,
+		reason:     &reason,
+	}
+	ctx = context.WithValue(ctx, cancelReasonKey{}, state.reason)
+	t.transitionsByActor[actorKey] = state
 
 	for _, action := range actions {
-		f(action)
+		action := action
+		f(state.id, func() error {
+			return action(ctx)
+		})
 	}
 }
 
-func (t *manager) CompleteTransitionAction(actorKey actor.Key, result error, resultFunc func(results chan error)) {
-	results, ok := t.transitionsByActorChan[actorKey]
-	if !ok {
+// resolveActions returns the actions to execute when the given actor transitions from the srcStatus to the
+// destStatus. Actions registered for the specific srcStatus take precedence over wildcard actions. The second return
+// value is false if no actions have been registered for the transition.
+func (t *manager) resolveActions(actorKey actor.Key, srcStatus actor.Status, destStatus actor.Status) ([]ContextAction, bool) {
+	lineage := t.lineage(actorKey, srcStatus)
+	for _, status := range lineage {
+		if actions, ok := t.transitionActionsByActor[actorKey][status][destStatus]; ok {
+			return actions, true
+		}
+	}
+	// Wildcards do not apply when the actor is already in the destStatus.
+	for _, status := range lineage {
+		if status == destStatus {
+			return nil, false
+		}
+	}
+
+	var actions []ContextAction
+	for _, wildcardActions := range t.wildcardActionsByActor[actorKey][destStatus] {
+		if wildcardActions.excludes(lineage) {
+			continue
+		}
+		actions = append(actions, wildcardActions.Actions...)
+	}
+	return actions, len(actions) > 0
+}
+
+func (t *manager) CompleteTransitionAction(actorKey actor.Key, transitionId uint64, result error, resultFunc func(results chan error)) {
+	state, ok := t.transitionsByActor[actorKey]
+	// Discard results which belong to a transition that has since been cancelled.
+	if !ok || state.id != transitionId {
 		return
 	}
+	results := state.results
 	results <- result
 	// If the channel has all results, then execute resultFunc with the results.
 	if len(results) == cap(results) {
 		close(results)
-		delete(t.transitionsByActorChan, actorKey)
+		delete(t.transitionsByActor, actorKey)
+		state.cancel()
 
 		resultFunc(results)
 	}
 }
+
+func (t *manager) CancelTransition(actorKey actor.Key, reason string) bool {
+	state, ok := t.transitionsByActor[actorKey]
+	if !ok {
+		return false
+	}
+	*state.reason = reason
+	state.cancel()
+	delete(t.transitionsByActor, actorKey)
+
+	return true
+}
+
+func (t *manager) GetInFlightTransition(actorKey actor.Key) (InFlightTransition, bool) {
+	state, ok := t.transitionsByActor[actorKey]
+	if !ok {
+		return InFlightTransition{}, false
+	}
+	return InFlightTransition{
+		SrcStatus:          state.srcStatus,
+		DestStatus:         state.destStatus,
+		OutstandingActions: cap(state.results) - len(state.results),
+	}, true
+}
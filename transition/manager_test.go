@@ -1,6 +1,7 @@
 package transition
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -96,7 +97,7 @@ func TestStartTransition(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.msg, func(t *testing.T) {
 			numTimesInvoked := 0
-			mgr.StartTransition(test.actorKey, test.srcStatus, test.destStatus, func(a Action) {
+			mgr.StartTransition(test.actorKey, test.srcStatus, test.destStatus, func(_ uint64, a Action) {
 				numTimesInvoked += 1
 			})
 
@@ -128,10 +129,13 @@ func TestCompleteTransitionAction_OneResult(t *testing.T) {
 			mgr.AddTransitionAction(ActorKey, SrcStatus, DestStatus, func() error {
 				return nil
 			})
-			mgr.StartTransition(ActorKey, SrcStatus, DestStatus, func(a Action) {})
+			var transitionId uint64
+			mgr.StartTransition(ActorKey, SrcStatus, DestStatus, func(id uint64, a Action) {
+				transitionId = id
+			})
 
 			resultFuncCalled := false
-			mgr.CompleteTransitionAction(test.actorKey, test.result, func(results chan error) {
+			mgr.CompleteTransitionAction(test.actorKey, transitionId, test.result, func(results chan error) {
 				resultFuncCalled = true
 
 				assert.Equal(t, test.result, <-results)
@@ -151,18 +155,237 @@ func TestCompleteTransitionAction_TwoResults(t *testing.T) {
 	mgr.AddTransitionAction(ActorKey, SrcStatus, DestStatus, func() error {
 		return nil
 	})
-	mgr.StartTransition(ActorKey, SrcStatus, DestStatus, func(a Action) {})
+	var transitionId uint64
+	mgr.StartTransition(ActorKey, SrcStatus, DestStatus, func(id uint64, a Action) {
+		transitionId = id
+	})
 	// Complete the first action. The function should not be called yet.
 	resultFuncCalled := false
-	mgr.CompleteTransitionAction(ActorKey, nil, func(results chan error) {
+	mgr.CompleteTransitionAction(ActorKey, transitionId, nil, func(results chan error) {
 		resultFuncCalled = true
 	})
 	assert.False(t, resultFuncCalled)
 	// Complet the section action. Verify the given function is called.
-	mgr.CompleteTransitionAction(ActorKey, nil, func(results chan error) {
+	mgr.CompleteTransitionAction(ActorKey, transitionId, nil, func(results chan error) {
 		resultFuncCalled = true
 
 		assert.Nil(t, <-results)
 	})
 	assert.True(t, resultFuncCalled)
 }
+
+func TestGetTransitionGuards(t *testing.T) {
+	mgr := NewManager()
+	mgr.AddTransitionGuard(ActorKey, SrcStatus, DestStatus, func() bool { return true })
+	mgr.AddTransitionGuard(ActorKey, SrcStatus, DestStatus, func() bool { return false })
+
+	assert.Len(t, mgr.GetTransitionGuards(ActorKey, SrcStatus, DestStatus), 2)
+	assert.Empty(t, mgr.GetTransitionGuards(ActorKey, SrcStatus, MissingStatus))
+	assert.Empty(t, mgr.GetTransitionGuards(ActorKey, MissingStatus, DestStatus))
+	assert.Empty(t, mgr.GetTransitionGuards(InvalidActorKey, SrcStatus, DestStatus))
+}
+
+func TestIsValidTransition_Wildcard(t *testing.T) {
+	mgr := NewManager()
+	mgr.AddTransitionAction(ActorKey, AnyStatus, DestStatus, func() error { return nil })
+	mgr.AddWildcardTransitionAction(ActorKey, []actor.Status{SrcStatus}, DepStatus, func() error { return nil })
+
+	tests := []*IsValidTransitionTest{
+		{actorKey: ActorKey, srcStatus: MissingStatus, destStatus: DestStatus, result: true,
+			msg: "return true when a wildcard transition exists for the destStatus"},
+		{actorKey: ActorKey, srcStatus: DestStatus, destStatus: DestStatus, result: false,
+			msg: "return false when transitioning to the same status"},
+		{actorKey: ActorKey, srcStatus: SrcStatus, destStatus: DepStatus, result: false,
+			msg: "return false when the srcStatus is excluded from the wildcard"},
+		{actorKey: ActorKey, srcStatus: MissingStatus, destStatus: DepStatus, result: true,
+			msg: "return true when the srcStatus is not excluded from the wildcard"},
+		{actorKey: InvalidActorKey, srcStatus: MissingStatus, destStatus: DestStatus, result: false,
+			msg: "return false when no wildcard transition exists for the given actor"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.msg, func(t *testing.T) {
+			isValidTransition := mgr.IsValidTransition(test.actorKey, test.srcStatus, test.destStatus)
+			assert.Equal(t, test.result, isValidTransition)
+		})
+	}
+}
+
+func TestStartTransition_WildcardPrecedence(t *testing.T) {
+	mgr := NewManager()
+
+	specificCalled, wildcardCalled := false, false
+	mgr.AddTransitionAction(ActorKey, SrcStatus, DestStatus, func() error {
+		specificCalled = true
+		return nil
+	})
+	mgr.AddTransitionAction(ActorKey, AnyStatus, DestStatus, func() error {
+		wildcardCalled = true
+		return nil
+	})
+
+	// The specific action takes precedence over the wildcard action.
+	mgr.StartTransition(ActorKey, SrcStatus, DestStatus, func(_ uint64, a Action) {
+		_ = a()
+	})
+	assert.True(t, specificCalled)
+	assert.False(t, wildcardCalled)
+
+	// The wildcard action is used when there is no specific action.
+	specificCalled = false
+	mgr.StartTransition(ActorKey, DepStatus, DestStatus, func(_ uint64, a Action) {
+		_ = a()
+	})
+	assert.False(t, specificCalled)
+	assert.True(t, wildcardCalled)
+}
+
+func TestCancelTransition(t *testing.T) {
+	mgr := NewManager()
+
+	var actionCtx context.Context
+	mgr.AddTransitionContextAction(ActorKey, SrcStatus, DestStatus, func(ctx context.Context) error {
+		actionCtx = ctx
+		return nil
+	})
+
+	var action Action
+	var cancelledId uint64
+	mgr.StartTransition(ActorKey, SrcStatus, DestStatus, func(id uint64, a Action) {
+		cancelledId = id
+		action = a
+	})
+	result := action()
+	assert.Nil(t, result)
+	assert.Nil(t, actionCtx.Err())
+
+	assert.True(t, mgr.CancelTransition(ActorKey, "test"))
+	assert.ErrorIs(t, actionCtx.Err(), context.Canceled)
+	assert.Equal(t, "test", CancelReason(actionCtx))
+
+	// There is no longer an in-flight transition to cancel.
+	assert.False(t, mgr.CancelTransition(ActorKey, "test"))
+
+	// Start a new transition. The late result from the cancelled transition should be discarded.
+	var transitionId uint64
+	mgr.StartTransition(ActorKey, SrcStatus, DestStatus, func(id uint64, a Action) {
+		transitionId = id
+	})
+	mgr.CompleteTransitionAction(ActorKey, cancelledId, result, func(results chan error) {
+		assert.Fail(t, "results from a cancelled transition should be discarded")
+	})
+
+	resultFuncCalled := false
+	mgr.CompleteTransitionAction(ActorKey, transitionId, nil, func(results chan error) {
+		resultFuncCalled = true
+	})
+	assert.True(t, resultFuncCalled)
+}
+
+func TestFindPath(t *testing.T) {
+	mgr := NewManager()
+	noop := func() error { return nil }
+	// SrcStatus -> DepStatus -> MissingStatus -> DestStatus
+	// SrcStatus -> DepStatus -> DestStatus
+	mgr.AddTransitionAction(ActorKey, SrcStatus, DepStatus, noop)
+	mgr.AddTransitionAction(ActorKey, DepStatus, MissingStatus, noop)
+	mgr.AddTransitionAction(ActorKey, MissingStatus, DestStatus, noop)
+	mgr.AddWildcardTransitionAction(ActorKey, []actor.Status{SrcStatus}, DestStatus, noop)
+	canTransitionFrom := func(actor.Status) bool { return true }
+
+	path, ok := mgr.FindPath(ActorKey, SrcStatus, DestStatus, canTransitionFrom)
+	assert.True(t, ok)
+	assert.Equal(t, []actor.Status{DepStatus, DestStatus}, path)
+
+	// A status which cannot be transitioned from cannot be used as an intermediate status.
+	path, ok = mgr.FindPath(ActorKey, SrcStatus, DestStatus, func(status actor.Status) bool {
+		return status != DepStatus
+	})
+	assert.False(t, ok)
+	assert.Nil(t, path)
+
+	_, ok = mgr.FindPath(ActorKey, DestStatus, SrcStatus, canTransitionFrom)
+	assert.False(t, ok)
+
+	_, ok = mgr.FindPath(InvalidActorKey, SrcStatus, DestStatus, canTransitionFrom)
+	assert.False(t, ok)
+}
+
+func TestIsValidTransition_ParentStatus(t *testing.T) {
+	const (
+		ParentStatus = "Parent"
+		ChildStatus  = "Parent.Child"
+		OtherStatus  = "Parent.Other"
+	)
+	mgr := NewManager(WithLineageFunc(func(actorKey actor.Key, status actor.Status) []actor.Status {
+		if status == ChildStatus || status == OtherStatus {
+			return []actor.Status{status, ParentStatus}
+		}
+		return []actor.Status{status}
+	}))
+
+	parentCalled, childCalled := false, false
+	mgr.AddTransitionAction(ActorKey, ParentStatus, DestStatus, func() error {
+		parentCalled = true
+		return nil
+	})
+	mgr.AddTransitionAction(ActorKey, OtherStatus, DestStatus, func() error {
+		childCalled = true
+		return nil
+	})
+	mgr.AddWildcardTransitionAction(ActorKey, []actor.Status{ParentStatus}, DepStatus, func() error { return nil })
+
+	// Transitions defined on the parent apply to its children.
+	assert.True(t, mgr.IsValidTransition(ActorKey, ChildStatus, DestStatus))
+	// Excluding the parent from a wildcard also excludes its children.
+	assert.False(t, mgr.IsValidTransition(ActorKey, ChildStatus, DepStatus))
+
+	// Transitions defined on the child take precedence over the parent.
+	mgr.StartTransition(ActorKey, OtherStatus, DestStatus, func(_ uint64, a Action) {
+		_ = a()
+	})
+	assert.True(t, childCalled)
+	assert.False(t, parentCalled)
+
+	mgr.StartTransition(ActorKey, ChildStatus, DestStatus, func(_ uint64, a Action) {
+		_ = a()
+	})
+	assert.True(t, parentCalled)
+
+	// Paths include transitions defined on the parent.
+	path, ok := mgr.FindPath(ActorKey, ChildStatus, DestStatus, func(actor.Status) bool { return true })
+	assert.True(t, ok)
+	assert.Equal(t, []actor.Status{DestStatus}, path)
+}
+
+func TestGetInFlightTransition(t *testing.T) {
+	mgr := NewManager()
+	mgr.AddTransitionAction(ActorKey, SrcStatus, DestStatus, func() error {
+		return nil
+	})
+	mgr.AddTransitionAction(ActorKey, SrcStatus, DestStatus, func() error {
+		return nil
+	})
+
+	_, ok := mgr.GetInFlightTransition(ActorKey)
+	assert.False(t, ok)
+
+	var transitionId uint64
+	var actions []Action
+	mgr.StartTransition(ActorKey, SrcStatus, DestStatus, func(id uint64, a Action) {
+		transitionId = id
+		actions = append(actions, a)
+	})
+	inFlight, ok := mgr.GetInFlightTransition(ActorKey)
+	assert.True(t, ok)
+	assert.Equal(t, InFlightTransition{SrcStatus: SrcStatus, DestStatus: DestStatus, OutstandingActions: 2}, inFlight)
+
+	mgr.CompleteTransitionAction(ActorKey, transitionId, actions[0](), func(results chan error) {})
+	inFlight, _ = mgr.GetInFlightTransition(ActorKey)
+	assert.Equal(t, 1, inFlight.OutstandingActions)
+
+	// The transition is no longer in-flight once all of its actions have completed.
+	mgr.CompleteTransitionAction(ActorKey, transitionId, actions[1](), func(results chan error) {})
+	_, ok = mgr.GetInFlightTransition(ActorKey)
+	assert.False(t, ok)
+}
@@ -1,18 +1,102 @@
 package transition
 
 import (
+	"context"
 	"github.com/strategicpause/slashie/actor"
 )
 
-// TransitionAction encapsulates a callback for when an actor transitions from SrcStatus to DestStatus.
+// AnyStatus can be used as the source status of a transition action to indicate that the action applies when
+// transitioning from any status to the destination status. Actions registered for a specific source status take
+// precedence over actions registered with AnyStatus.
+const AnyStatus actor.Status = "*"
+
+// TransitionAction encapsulates a callback for when an actor transitions from SrcStatus to DestStatus. If SrcStatus
+// is AnyStatus, then ExcludedStatuses can be used to prevent the action from applying to the given source statuses.
 type TransitionAction struct {
-	SrcStatus  actor.Status
-	DestStatus actor.Status
-	Action     Action
+	SrcStatus        actor.Status
+	DestStatus       actor.Status
+	Action           Action
+	ExcludedStatuses []actor.Status
 }
 
 // Action actor to register a callback to execute when i
 type Action func() error
 
+// ContextAction is an Action which receives a context that is cancelled if the transition is cancelled while the
+// action is running.
+type ContextAction func(ctx context.Context) error
+
 // ActionsByStatus
-type ActionsByStatus map[actor.Status]map[actor.Status][]Action
+type ActionsByStatus map[actor.Status]map[actor.Status][]ContextAction
+
+// Guard is a predicate which must return true for an actor to begin transitioning from one status to another.
+type Guard func() bool
+
+// GuardsByStatus
+type GuardsByStatus map[actor.Status]map[actor.Status][]Guard
+
+// WildcardActions are the actions to execute when transitioning from any status, except for ExcludedStatuses, to a
+// given destination status.
+type WildcardActions struct {
+	ExcludedStatuses map[actor.Status]struct{}
+	Actions          []ContextAction
+}
+
+// excludes returns true if any of the given statuses have been excluded.
+func (w *WildcardActions) excludes(statuses []actor.Status) bool {
+	for _, status := range statuses {
+		if _, ok := w.ExcludedStatuses[status]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// LineageFunc returns the given status followed by each of its ancestors for the given actor, ordered from the
+// innermost to the outermost status.
+type LineageFunc func(actorKey actor.Key, status actor.Status) []actor.Status
+
+// WildcardActionsByStatus
+type WildcardActionsByStatus map[actor.Status][]*WildcardActions
+
+// cancelReasonKey is the context key used to store the reason a transition was cancelled.
+type cancelReasonKey struct{}
+
+// CancelReason returns the reason given for cancelling the transition which the context belongs to. An empty string
+// is returned if the transition has not been cancelled.
+func CancelReason(ctx context.Context) string {
+	if ctx.Err() == nil {
+		return ""
+	}
+	if reason, ok := ctx.Value(cancelReasonKey{}).(*string); ok {
+		return *reason
+	}
+	return ""
+}
+
+// InFlightTransition describes a transition whose actions are running.
+type InFlightTransition struct {
+	SrcStatus  actor.Status
+	DestStatus actor.Status
+	// OutstandingActions is the number of actions which have not yet completed.
+	OutstandingActions int
+}
+
+// transitionState tracks the actions which are running for an in-flight transition.
+type transitionState struct {
+	srcStatus  actor.Status
+	destStatus actor.Status
+	// id uniquely identifies the transition for an actor, so that results from a cancelled transition can be discarded.
+	id      uint64
+	results chan error
+	cancel  context.CancelFunc
+	// reason is populated before cancel is called, so that it is visible to actions once the context is done.
+	reason *string
+}
+
+// withContext adapts an Action into a ContextAction.
+func withContext(action Action) ContextAction {
+	return func(_ context.Context) error {
+		return action()
+	}
+}